@@ -0,0 +1,331 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func benchmarkLevelDat(b *testing.B) []byte {
+	buf := new(bytes.Buffer)
+	w := NewWriter(buf)
+
+	check := func(err error) {
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	check(w.BeginCompound(""))
+	check(w.BeginCompound("Data"))
+	check(w.Int("DataVersion", 3465))
+	check(w.String("LevelName", "New World"))
+	check(w.Long("Time", 1234567))
+	check(w.Long("DayTime", 7654321))
+	check(w.Byte("hardcore", 0))
+	check(w.BeginCompound("GameRules"))
+	for i := 0; i < 50; i++ {
+		check(w.String(fmt.Sprintf("rule%d", i), "true"))
+	}
+	check(w.End())
+	check(w.BeginCompound("Player"))
+	check(w.BeginList("Pos", TagTypeDouble, 3))
+	for _, v := range []float64{0.5, 64, -12.25} {
+		check(w.Double("", v))
+	}
+	check(w.End())
+	check(w.BeginList("Inventory", TagTypeCompound, 36))
+	for i := 0; i < 36; i++ {
+		check(w.BeginCompound(""))
+		check(w.Byte("Slot", int8(i)))
+		check(w.String("id", "minecraft:stone"))
+		check(w.Byte("Count", 64))
+		check(w.End())
+	}
+	check(w.End())
+	check(w.IntArray("UUID", []int32{1, 2, 3, 4}))
+	check(w.End())
+	check(w.End())
+	check(w.End())
+	check(w.Close())
+
+	return buf.Bytes()
+}
+
+func benchmarkChunk(b *testing.B) []byte {
+	buf := new(bytes.Buffer)
+	w := NewWriter(buf)
+
+	check := func(err error) {
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	longs := make([]int64, 256)
+	for i := range longs {
+		longs[i] = int64(i) * 0x0123456789
+	}
+
+	check(w.BeginCompound(""))
+	check(w.Int("DataVersion", 3465))
+	check(w.String("Status", "minecraft:full"))
+	check(w.Long("InhabitedTime", 12345))
+	check(w.BeginList("sections", TagTypeCompound, 24))
+	for i := 0; i < 24; i++ {
+		check(w.BeginCompound(""))
+		check(w.Byte("Y", int8(i-4)))
+		check(w.BeginCompound("block_states"))
+		check(w.BeginList("palette", TagTypeCompound, 4))
+		for j := 0; j < 4; j++ {
+			check(w.BeginCompound(""))
+			check(w.String("Name", "minecraft:stone"))
+			check(w.End())
+		}
+		check(w.End())
+		check(w.LongArray("data", longs))
+		check(w.End())
+		check(w.ByteArray("BlockLight", make([]int8, 2048)))
+		check(w.ByteArray("SkyLight", make([]int8, 2048)))
+		check(w.End())
+	}
+	check(w.End())
+	check(w.BeginCompound("Heightmaps"))
+	for _, name := range []string{"MOTION_BLOCKING", "MOTION_BLOCKING_NO_LEAVES", "OCEAN_FLOOR", "WORLD_SURFACE"} {
+		check(w.LongArray(name, longs[:37]))
+	}
+	check(w.End())
+	check(w.End())
+	check(w.Close())
+
+	return buf.Bytes()
+}
+
+func benchmarkDecode(b *testing.B, raw []byte, decode func(r *bytes.Reader) error) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(raw)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := decode(bytes.NewReader(raw)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecode_levelDat(b *testing.B) {
+	benchmarkDecode(b, benchmarkLevelDat(b), func(r *bytes.Reader) error {
+		_, err := Decode(r)
+		return err
+	})
+}
+
+func BenchmarkDecode_chunk(b *testing.B) {
+	benchmarkDecode(b, benchmarkChunk(b), func(r *bytes.Reader) error {
+		_, err := Decode(r)
+		return err
+	})
+}
+
+// NOTE: reads from a file, each Read without buffering is a system call
+func BenchmarkDecode_file(b *testing.B) {
+	raw := benchmarkChunk(b)
+
+	f, err := os.Create(filepath.Join(b.TempDir(), "chunk.nbt"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(raw); err != nil {
+		b.Fatal(err)
+	}
+
+	run := func(b *testing.B, wrap func(r io.Reader) io.Reader) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(raw)))
+
+		for i := 0; i < b.N; i++ {
+			if _, err := f.Seek(0, io.SeekStart); err != nil {
+				b.Fatal(err)
+			}
+
+			if _, err := Decode(wrap(f)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+
+	b.Run("unbuffered", func(b *testing.B) {
+		run(b, func(r io.Reader) io.Reader { return r })
+	})
+
+	b.Run("bufio", func(b *testing.B) {
+		run(b, func(r io.Reader) io.Reader { return bufio.NewReader(r) })
+	})
+}
+
+func BenchmarkDecodeSelect_chunk(b *testing.B) {
+	benchmarkDecode(b, benchmarkChunk(b), func(r *bytes.Reader) error {
+		_, err := DecodeSelect(r, "Status", "InhabitedTime")
+		return err
+	})
+}
+
+func BenchmarkDecoder_chunk(b *testing.B) {
+	benchmarkDecode(b, benchmarkChunk(b), func(r *bytes.Reader) error {
+		_, err := NewDecoder(r).Decode()
+		return err
+	})
+}
+
+func BenchmarkDecodeBytes_chunk(b *testing.B) {
+	raw := benchmarkChunk(b)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(raw)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, _, err := DecodeBytes(raw); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeBytesWithOptions_chunk(b *testing.B) {
+	raw := benchmarkChunk(b)
+	opts := &DecodeOptions{ShareStrings: true, Arrays: NewArrayPool()}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(raw)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		tag, _, err := DecodeBytesWithOptions(raw, opts)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		opts.Arrays.Release(tag.Payload())
+	}
+}
+
+func BenchmarkEncode_chunk(b *testing.B) {
+	tag, err := Decode(bytes.NewReader(benchmarkChunk(b)))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		if err := Encode(buf, tag); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAppendEncode_chunk(b *testing.B) {
+	tag, err := Decode(bytes.NewReader(benchmarkChunk(b)))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	dst := make([]byte, 0, EncodedSize(tag))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if dst, err = AppendEncode(dst[:0], tag); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStringify_chunk(b *testing.B) {
+	tag, err := Decode(bytes.NewReader(benchmarkChunk(b)))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = PrettyStringify(tag, "  ")
+	}
+}
+
+func BenchmarkWriteSNBT_chunk(b *testing.B) {
+	tag, err := Decode(bytes.NewReader(benchmarkChunk(b)))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	opts := &StringifyOptions{Space: " ", Indent: "  "}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := WriteSNBT(io.Discard, tag, opts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParse_namedEscape(b *testing.B) {
+	const snbt = `{name: "\N{SNOWMAN} \N{latin small letter e with acute} \N{CJK UNIFIED IDEOGRAPH-4E00}"}`
+
+	b.Run("first", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			runesByPrefixOnce = sync.Once{}
+			if _, err := Parse(snbt); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			if _, err := Parse(snbt); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
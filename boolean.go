@@ -0,0 +1,105 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"regexp"
+)
+
+var booleanPattern = regexp.MustCompile(`^(?i:(true)|false)$`)
+
+type BooleanStyle byte
+
+const (
+	// NOTE: 1b and 0b
+	BooleanStyleByte BooleanStyle = iota
+	// NOTE: true and false for Byte tags whose name is in BooleanKeys
+	BooleanStyleKnownKeys
+	// NOTE: true and false for every Byte holding 1 or 0, including list elements
+	BooleanStyleAll
+)
+
+// NOTE: used by BooleanStyleKnownKeys when StringifyOptions.BooleanKeys is nil
+var DefaultBooleanKeys = []string{
+	"Bred",
+	"CanPickUpLoot",
+	"CannotHunt",
+	"ChestedHorse",
+	"CustomNameVisible",
+	"DifficultyLocked",
+	"EatingHaystack",
+	"FallFlying",
+	"Glowing",
+	"HasNectar",
+	"HasStung",
+	"Invisible",
+	"Invulnerable",
+	"IsBaby",
+	"IsImmuneToZombification",
+	"LeftHanded",
+	"MapFeatures",
+	"Marker",
+	"NoAI",
+	"NoBasePlate",
+	"NoGravity",
+	"OnGround",
+	"PersistenceRequired",
+	"PlayerCreated",
+	"Saddle",
+	"Sheared",
+	"ShowArms",
+	"Silent",
+	"Sitting",
+	"Small",
+	"Tame",
+	"Unbreakable",
+	"allowCommands",
+	"hardcore",
+	"initialized",
+	"raining",
+	"thundering",
+}
+
+func (o *StringifyOptions) isBooleanKey(name string) bool {
+	keys := o.BooleanKeys
+	if keys == nil {
+		keys = DefaultBooleanKeys
+	}
+
+	for _, key := range keys {
+		if key == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func appendBoolean(dst []byte, v int8) ([]byte, bool) {
+	switch v {
+	case 0:
+		return append(dst, "false"...), true
+	case 1:
+		return append(dst, "true"...), true
+	default:
+		return dst, false
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_boolean(t *testing.T) {
+	cases := []struct {
+		name     string
+		snbt     string
+		expected Tag
+	}{
+		{
+			name: `positive case: compound`,
+			snbt: `{Invulnerable: true, NoAI: false, Silent: TRUE}`,
+			expected: NewCompoundTag(NewTagName(``), NewCompoundPayload(
+				NewByteTag(NewTagName(`Invulnerable`), NewBytePayload(1)),
+				NewByteTag(NewTagName(`NoAI`), NewBytePayload(0)),
+				NewByteTag(NewTagName(`Silent`), NewBytePayload(1)),
+				NewEndTag(),
+			)),
+		},
+		{
+			name: `positive case: list`,
+			snbt: `{Flags: [true, false, 1b]}`,
+			expected: NewCompoundTag(NewTagName(``), NewCompoundPayload(
+				NewListTag(NewTagName(`Flags`), NewListPayload(NewBytePayload(1), NewBytePayload(0), NewBytePayload(1))),
+				NewEndTag(),
+			)),
+		},
+		{
+			name: `positive case: quoted is a string`,
+			snbt: `{Name: "true", Other: 'false'}`,
+			expected: NewCompoundTag(NewTagName(``), NewCompoundPayload(
+				NewStringTag(NewTagName(`Name`), NewStringPayload(`true`)),
+				NewStringTag(NewTagName(`Other`), NewStringPayload(`false`)),
+				NewEndTag(),
+			)),
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := Parse(tt.snbt)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestStringifyWithOptions_boolean(t *testing.T) {
+	tag := NewCompoundTag(NewTagName(``), NewCompoundPayload(
+		NewByteTag(NewTagName(`Count`), NewBytePayload(1)),
+		NewListTag(NewTagName(`Flags`), NewListPayload(NewBytePayload(0), NewBytePayload(1))),
+		NewByteTag(NewTagName(`Invulnerable`), NewBytePayload(1)),
+		NewByteTag(NewTagName(`NoAI`), NewBytePayload(2)),
+		NewEndTag(),
+	))
+
+	cases := []struct {
+		name     string
+		opts     *StringifyOptions
+		expected string
+	}{
+		{
+			name:     `positive case: byte`,
+			opts:     &StringifyOptions{Space: " "},
+			expected: `{Count: 1b, Flags: [0b, 1b], Invulnerable: 1b, NoAI: 2b}`,
+		},
+		{
+			name:     `positive case: known keys`,
+			opts:     &StringifyOptions{Space: " ", Booleans: BooleanStyleKnownKeys},
+			expected: `{Count: 1b, Flags: [0b, 1b], Invulnerable: true, NoAI: 2b}`,
+		},
+		{
+			name:     `positive case: custom keys`,
+			opts:     &StringifyOptions{Space: " ", Booleans: BooleanStyleKnownKeys, BooleanKeys: []string{"Count"}},
+			expected: `{Count: true, Flags: [0b, 1b], Invulnerable: 1b, NoAI: 2b}`,
+		},
+		{
+			name:     `positive case: all`,
+			opts:     &StringifyOptions{Space: " ", Booleans: BooleanStyleAll},
+			expected: `{Count: true, Flags: [false, true], Invulnerable: true, NoAI: 2b}`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := StringifyWithOptions(tag, tt.opts)
+			assert.Equal(t, tt.expected, actual)
+
+			parsed, err := Parse(actual)
+			assert.NoError(t, err)
+			assert.Equal(t, tag, parsed)
+		})
+	}
+}
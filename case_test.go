@@ -1463,6 +1463,49 @@ var listTagCases = []tagTestCase[*ListPayload]{
 			},
 		},
 	},
+	{
+		name: `positive case: ListTag - empty Compound`,
+		nbt: nbtTestCase[*ListPayload]{
+			tagType: TagTypeList,
+			tagName: `List`,
+			payload: NewEmptyListPayload(TagTypeCompound),
+		},
+		snbt: snbtTestCase{
+			tagName: `List`,
+			payload: stringifyType{
+				typeDefault: `[]`,
+				typeCompact: `[]`,
+				typePretty:  `[]`,
+			},
+		},
+		json: jsonTestCase{
+			tagName: `"List"`,
+			payload: stringifyType{
+				typeDefault: `[]`,
+				typeCompact: `[]`,
+				typePretty:  `[]`,
+			},
+		},
+		raw: rawTestCase{
+			tagType: []byte{
+				// Type: List(=9)
+				0x09,
+			},
+			tagName: []byte{
+				// Name Length: 4
+				0x00, 0x04,
+				// Name: List
+				0x4C, 0x69, 0x73, 0x74,
+			},
+			payload: []byte{
+				// Payload Type: TagCompound(=10)
+				0x0A,
+				// Payload Length: 0
+				0x00, 0x00, 0x00, 0x00,
+				// Payload: []
+			},
+		},
+	},
 }
 
 var compoundTagCases = []tagTestCase[*CompoundPayload]{
@@ -0,0 +1,153 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"context"
+	"io"
+	"log/slog"
+)
+
+// NOTE: a nil Logger falls back to the logger set by SetSlogLogger or SetLogger
+
+type EncodeOptions struct {
+	Logger *slog.Logger
+
+	ctx context.Context
+}
+
+type DecodeOptions struct {
+	// NOTE: keep nested ByteArray, List, Compound, IntArray and LongArray payloads as RawPayload
+	Lazy bool
+	// NOTE: only for DecodeBytes, decoded strings share memory with the input which must not be modified afterwards
+	ShareStrings bool
+	// NOTE: ByteArray, IntArray and LongArray payloads are taken from the pool when set
+	Arrays *ArrayPool
+	// NOTE: elements of compound lists wrapped under an empty name, as 1.21.5 writes heterogeneous lists, are unwrapped
+	UnwrapLists bool
+	Logger      *slog.Logger
+
+	ctx       context.Context
+	selection selection
+}
+
+type StringifyOptions struct {
+	Space    string
+	Indent   string
+	Booleans BooleanStyle
+	// NOTE: names of Byte tags written as true or false with BooleanStyleKnownKeys, DefaultBooleanKeys when nil
+	BooleanKeys []string
+	// NOTE: only changes quoting here, StringifyDialectWithOptions also converts and checks values
+	Dialect Dialect
+	// NOTE: used by StringifyDialectWithOptions, DefaultTextComponentKeys when nil
+	TextComponentKeys []string
+	KeyOrder          KeyOrder
+	QuoteStyle        QuoteStyle
+	// NOTE: with Indent, lists and arrays whose one-line form is at most CollapseWidth bytes are written on one line
+	CollapseWidth int
+	// NOTE: with Indent, arrays are written ArrayWrap values per line, on one line when 0
+	ArrayWrap       int
+	TrailingNewline bool
+	Color           ColorMode
+	// NOTE: DefaultPalette when nil
+	Palette *Palette
+	Logger  *slog.Logger
+}
+
+type ParseOptions struct {
+	// NOTE: accepts comments and trailing commas, valid SNBT parses the same either way
+	Lenient bool
+	Logger  *slog.Logger
+}
+
+type JsonOptions struct {
+	Space     string
+	Indent    string
+	NonFinite NonFiniteStyle
+	Logger    *slog.Logger
+
+	err error
+}
+
+// NOTE: nil options read as the zero value through these, so that only methods reading other fields normalise nil
+func (o *EncodeOptions) logger() *slog.Logger {
+	if o == nil {
+		return nil
+	}
+
+	return o.Logger
+}
+
+func (o *DecodeOptions) logger() *slog.Logger {
+	if o == nil {
+		return nil
+	}
+
+	return o.Logger
+}
+
+func (o *StringifyOptions) logger() *slog.Logger {
+	if o == nil {
+		return nil
+	}
+
+	return o.Logger
+}
+
+func (o *ParseOptions) logger() *slog.Logger {
+	if o == nil {
+		return nil
+	}
+
+	return o.Logger
+}
+
+func (o *JsonOptions) logger() *slog.Logger {
+	if o == nil {
+		return nil
+	}
+
+	return o.Logger
+}
+
+type NbtEncoder interface {
+	EncodeNBT(w io.Writer, opts *EncodeOptions) error
+}
+
+type NbtAppender interface {
+	AppendNBT(dst []byte, opts *EncodeOptions) ([]byte, error)
+}
+
+type NbtSizer interface {
+	EncodedSize(opts *EncodeOptions) int
+}
+
+type NbtDecoder interface {
+	DecodeNBT(r io.Reader, opts *DecodeOptions) error
+}
+
+type SnbtAppender interface {
+	AppendSNBT(dst []byte, opts *StringifyOptions, depth int) []byte
+}
+
+type JsonAppender interface {
+	AppendJSON(dst []byte, opts *JsonOptions, depth int) []byte
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	nbt "github.com/Aton-Kish/gonbt"
+	"github.com/stretchr/testify/assert"
+)
+
+type upperStringPayload struct {
+	value string
+}
+
+func (p *upperStringPayload) String() string {
+	return string(p.AppendSNBT(nil, &nbt.StringifyOptions{Space: " "}, 0))
+}
+
+func (p *upperStringPayload) TypeId() nbt.TagType {
+	return nbt.TagTypeString
+}
+
+func (p *upperStringPayload) upper() *nbt.StringPayload {
+	return nbt.NewStringPayload(strings.ToUpper(p.value))
+}
+
+func (p *upperStringPayload) EncodeNBT(w io.Writer, opts *nbt.EncodeOptions) error {
+	return p.upper().EncodeNBT(w, opts)
+}
+
+func (p *upperStringPayload) DecodeNBT(r io.Reader, opts *nbt.DecodeOptions) error {
+	payload := new(nbt.StringPayload)
+	if err := payload.DecodeNBT(r, opts); err != nil {
+		return err
+	}
+
+	p.value = string(*payload)
+
+	return nil
+}
+
+func (p *upperStringPayload) AppendSNBT(dst []byte, opts *nbt.StringifyOptions, depth int) []byte {
+	return p.upper().AppendSNBT(dst, opts, depth)
+}
+
+func (p *upperStringPayload) AppendJSON(dst []byte, opts *nbt.JsonOptions, depth int) []byte {
+	return p.upper().AppendJSON(dst, opts, depth)
+}
+
+type wrappedTag struct {
+	tagName *nbt.TagName
+	payload nbt.Payload
+}
+
+func (t *wrappedTag) String() string {
+	return string(t.AppendSNBT(nil, &nbt.StringifyOptions{Space: " "}, 0))
+}
+
+func (t *wrappedTag) TypeId() nbt.TagType {
+	return t.payload.TypeId()
+}
+
+func (t *wrappedTag) TagName() *nbt.TagName {
+	return t.tagName
+}
+
+func (t *wrappedTag) Payload() nbt.Payload {
+	return t.payload
+}
+
+func (t *wrappedTag) EncodeNBT(w io.Writer, opts *nbt.EncodeOptions) error {
+	return nbt.Encode(w, t)
+}
+
+func (t *wrappedTag) DecodeNBT(r io.Reader, opts *nbt.DecodeOptions) error {
+	return t.payload.DecodeNBT(r, opts)
+}
+
+func (t *wrappedTag) AppendSNBT(dst []byte, opts *nbt.StringifyOptions, depth int) []byte {
+	dst = append(dst, t.tagName.String()...)
+	dst = append(dst, ':')
+	dst = append(dst, opts.Space...)
+	return t.payload.AppendSNBT(dst, opts, depth)
+}
+
+func (t *wrappedTag) AppendJSON(dst []byte, opts *nbt.JsonOptions, depth int) []byte {
+	dst = append(dst, '"')
+	dst = append(dst, *t.tagName...)
+	dst = append(dst, '"', ':')
+	dst = append(dst, opts.Space...)
+	return t.payload.AppendJSON(dst, opts, depth)
+}
+
+func TestExternalPayload(t *testing.T) {
+	cases := []struct {
+		name     string
+		nbt      nbt.Tag
+		expected nbt.Tag
+	}{
+		{
+			name: `positive case: Compound`,
+			nbt: nbt.NewCompoundTag(nbt.NewTagName(`Data`), nbt.NewCompoundPayload(
+				&wrappedTag{tagName: nbt.NewTagName(`Name`), payload: &upperStringPayload{`Steve`}},
+				nbt.NewEndTag(),
+			)),
+			expected: nbt.NewCompoundTag(nbt.NewTagName(`Data`), nbt.NewCompoundPayload(
+				nbt.NewStringTag(nbt.NewTagName(`Name`), nbt.NewStringPayload(`STEVE`)),
+				nbt.NewEndTag(),
+			)),
+		},
+		{
+			name: `positive case: List`,
+			nbt: nbt.NewListTag(nbt.NewTagName(`Names`), nbt.NewListPayload(
+				&upperStringPayload{`Steve`},
+				&upperStringPayload{`Alex`},
+			)),
+			expected: nbt.NewListTag(nbt.NewTagName(`Names`), nbt.NewListPayload(
+				nbt.NewStringPayload(`STEVE`),
+				nbt.NewStringPayload(`ALEX`),
+			)),
+		},
+		{
+			name:     `positive case: root`,
+			nbt:      &wrappedTag{tagName: nbt.NewTagName(`Name`), payload: &upperStringPayload{`Steve`}},
+			expected: nbt.NewStringTag(nbt.NewTagName(`Name`), nbt.NewStringPayload(`STEVE`)),
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			expected := new(bytes.Buffer)
+			err := nbt.Encode(expected, tt.expected)
+			assert.NoError(t, err)
+
+			actual := new(bytes.Buffer)
+			err = nbt.Encode(actual, tt.nbt)
+			assert.NoError(t, err)
+
+			assert.Equal(t, expected.Bytes(), actual.Bytes())
+
+			appended, err := nbt.AppendEncode(nil, tt.nbt)
+			assert.NoError(t, err)
+			assert.Equal(t, expected.Bytes(), appended)
+			assert.Equal(t, expected.Len(), nbt.EncodedSize(tt.nbt))
+
+			assert.Equal(t, nbt.Stringify(tt.expected), nbt.Stringify(tt.nbt))
+			assert.Equal(t, nbt.PrettyStringify(tt.expected, "  "), nbt.PrettyStringify(tt.nbt, "  "))
+			assert.Equal(t, nbt.Json(tt.expected), nbt.Json(tt.nbt))
+			assert.Equal(t, nbt.PrettyJson(tt.expected, "  "), nbt.PrettyJson(tt.nbt, "  "))
+		})
+	}
+}
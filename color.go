@@ -0,0 +1,128 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"io"
+	"os"
+)
+
+const ansiReset = "\x1b[0m"
+
+type ColorMode byte
+
+const (
+	ColorNever ColorMode = iota
+	// NOTE: colors only when the writer is a character device and NO_COLOR is not set
+	ColorAuto
+	ColorAlways
+)
+
+// NOTE: ANSI SGR sequences written before each part, an empty one leaves the part uncolored
+type Palette struct {
+	Key     string
+	String  string
+	Number  string
+	Suffix  string
+	Bracket string
+}
+
+// NOTE: close to /data get in game
+var DefaultPalette = &Palette{
+	Key:     "\x1b[96m",
+	String:  "\x1b[92m",
+	Number:  "\x1b[33m",
+	Suffix:  "\x1b[91m",
+	Bracket: "\x1b[97m",
+}
+
+func (o *StringifyOptions) palette(w io.Writer) *Palette {
+	switch o.Color {
+	case ColorAlways:
+	case ColorAuto:
+		if _, ok := os.LookupEnv("NO_COLOR"); ok || !isTerminal(w) {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	if o.Palette == nil {
+		return DefaultPalette
+	}
+
+	return o.Palette
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+func (sw *snbtWriter) colored(color string, b []byte) {
+	if sw.palette == nil || color == "" {
+		sw.buf = append(sw.buf, b...)
+		return
+	}
+
+	sw.buf = append(sw.buf, color...)
+	sw.buf = append(sw.buf, b...)
+	sw.buf = append(sw.buf, ansiReset...)
+}
+
+func (sw *snbtWriter) bracket(b ...byte) {
+	if sw.palette == nil {
+		sw.buf = append(sw.buf, b...)
+		return
+	}
+
+	sw.colored(sw.palette.Bracket, b)
+}
+
+// NOTE: splits a rendered number into its digits and its type suffix
+func (sw *snbtWriter) number(b []byte) {
+	if sw.palette == nil {
+		sw.buf = append(sw.buf, b...)
+		return
+	}
+
+	i := len(b)
+	if i > 0 {
+		switch b[i-1] {
+		case 'b', 's', 'L', 'f', 'd':
+			i--
+		}
+	}
+
+	sw.colored(sw.palette.Number, b[:i])
+	if i < len(b) {
+		sw.colored(sw.palette.Suffix, b[i:])
+	}
+}
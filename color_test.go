@@ -0,0 +1,97 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteSNBT_color(t *testing.T) {
+	tag := NewCompoundTag(NewTagName(``), NewCompoundPayload(
+		NewStringTag(NewTagName(`id`), NewStringPayload(`minecraft:pig`)),
+		NewByteTag(NewTagName(`NoAI`), NewBytePayload(1)),
+		NewIntArrayTag(NewTagName(`UUID`), NewIntArrayPayload(1, -2)),
+		NewListTag(NewTagName(`Pos`), NewListPayload(NewDoublePayload(0.5), NewDoublePayload(64))),
+		NewEndTag(),
+	))
+
+	palette := &Palette{Key: "<k>", String: "<s>", Number: "<n>", Suffix: "<t>", Bracket: "<b>"}
+	r := func(s string) string { return s + ansiReset }
+
+	cases := []struct {
+		name     string
+		opts     *StringifyOptions
+		expected string
+	}{
+		{
+			name: `positive case: always`,
+			opts: &StringifyOptions{Space: " ", Booleans: BooleanStyleKnownKeys, Color: ColorAlways, Palette: palette},
+			expected: r(`<b>{`) + r(`<k>NoAI`) + `: ` + r(`<n>true`) + `, ` +
+				r(`<k>Pos`) + `: ` + r(`<b>[`) + r(`<n>0.5`) + r(`<t>d`) + `, ` + r(`<n>64`) + r(`<t>d`) + r(`<b>]`) + `, ` +
+				r(`<k>UUID`) + `: ` + r(`<b>[`) + r(`<t>I`) + `; ` + r(`<n>1`) + `, ` + r(`<n>-2`) + r(`<b>]`) + `, ` +
+				r(`<k>id`) + `: ` + r(`<s>"minecraft:pig"`) + r(`<b>}`),
+		},
+		{
+			name:     `positive case: collapsed`,
+			opts:     &StringifyOptions{Space: " ", Indent: "  ", CollapseWidth: 12, KeyOrder: KeyOrderInsertion, Color: ColorAlways, Palette: &Palette{Number: "<n>"}},
+			expected: "{\n  id: \"minecraft:pig\",\n  NoAI: " + r(`<n>1`) + "b,\n  UUID: [I; " + r(`<n>1`) + ", " + r(`<n>-2`) + "],\n  Pos: [" + r(`<n>0.5`) + "d, " + r(`<n>64`) + "d]\n}",
+		},
+		{
+			name:     `positive case: auto is off for a buffer`,
+			opts:     &StringifyOptions{Space: " ", Color: ColorAuto, Palette: palette},
+			expected: `{NoAI: 1b, Pos: [0.5d, 64d], UUID: [I; 1, -2], id: "minecraft:pig"}`,
+		},
+		{
+			name:     `positive case: never`,
+			opts:     &StringifyOptions{Space: " ", Palette: palette},
+			expected: `{NoAI: 1b, Pos: [0.5d, 64d], UUID: [I; 1, -2], id: "minecraft:pig"}`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := WriteSNBT(buf, tag, tt.opts)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.snbt"))
+	assert.NoError(t, err)
+	defer f.Close()
+
+	assert.False(t, isTerminal(f))
+	assert.False(t, isTerminal(new(bytes.Buffer)))
+
+	opts := &StringifyOptions{Color: ColorAuto}
+	assert.Nil(t, opts.palette(f))
+
+	opts.Color = ColorAlways
+	assert.Equal(t, DefaultPalette, opts.palette(f))
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"context"
+	"io"
+)
+
+// NOTE: the context is checked on entering each Compound and List and every contextCheckInterval elements
+const contextCheckInterval = 1024
+
+func DecodeContext(ctx context.Context, r io.Reader) (Tag, error) {
+	return DecodeWithOptions(r, new(DecodeOptions).WithContext(ctx))
+}
+
+func EncodeContext(ctx context.Context, w io.Writer, tag Tag) error {
+	return EncodeWithOptions(w, tag, new(EncodeOptions).WithContext(ctx))
+}
+
+func (o *EncodeOptions) WithContext(ctx context.Context) *EncodeOptions {
+	var opts EncodeOptions
+	if o != nil {
+		opts = *o
+	}
+
+	opts.ctx = ctx
+	return &opts
+}
+
+func (o *EncodeOptions) Context() context.Context {
+	if o == nil || o.ctx == nil {
+		return context.Background()
+	}
+
+	return o.ctx
+}
+
+func (o *EncodeOptions) checkContext(i int) error {
+	if o == nil || o.ctx == nil || i%contextCheckInterval != 0 {
+		return nil
+	}
+
+	if err := o.ctx.Err(); err != nil {
+		return &NbtError{Op: "encode", Err: err}
+	}
+
+	return nil
+}
+
+func (o *DecodeOptions) WithContext(ctx context.Context) *DecodeOptions {
+	var opts DecodeOptions
+	if o != nil {
+		opts = *o
+	}
+
+	opts.ctx = ctx
+	return &opts
+}
+
+func (o *DecodeOptions) Context() context.Context {
+	if o == nil || o.ctx == nil {
+		return context.Background()
+	}
+
+	return o.ctx
+}
+
+func (o *DecodeOptions) checkContext(i int) error {
+	if o == nil || o.ctx == nil || i%contextCheckInterval != 0 {
+		return nil
+	}
+
+	if err := o.ctx.Err(); err != nil {
+		return &NbtError{Op: "decode", Err: err}
+	}
+
+	return nil
+}
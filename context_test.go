@@ -0,0 +1,189 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeContext(t *testing.T) {
+	for _, c := range nbtCases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := DecodeContext(context.Background(), bytes.NewBuffer(c.raw))
+			assert.NoError(t, err)
+			assert.Equal(t, c.nbt, actual)
+		})
+	}
+}
+
+func TestDecodeContext_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, c := range nbtCases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := DecodeContext(ctx, bytes.NewBuffer(c.raw))
+			assert.Nil(t, actual)
+			assert.Equal(t, &NbtError{Op: "decode", Err: context.Canceled}, err)
+			assert.ErrorIs(t, err, context.Canceled)
+		})
+	}
+}
+
+// NOTE: cancels the context once half of the input has been read
+type cancelReader struct {
+	r      *bytes.Reader
+	cancel context.CancelFunc
+}
+
+func (r *cancelReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if int64(r.r.Len()) < r.r.Size()/2 {
+		r.cancel()
+	}
+
+	return n, err
+}
+
+func TestDecodeContext_canceledInList(t *testing.T) {
+	values := make([]int32, 4*contextCheckInterval)
+	raw, err := AppendEncode(nil, NewListTag(NewTagName("List"), ListOf(values...)))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	actual, err := DecodeContext(ctx, &cancelReader{r: bytes.NewReader(raw), cancel: cancel})
+	assert.Nil(t, actual)
+	assert.Equal(t, &NbtError{Op: "decode", Err: context.Canceled}, err)
+}
+
+func TestEncodeContext(t *testing.T) {
+	for _, c := range nbtCases {
+		t.Run(c.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := EncodeContext(context.Background(), buf, c.nbt)
+			assert.NoError(t, err)
+			assert.Equal(t, c.raw, buf.Bytes())
+		})
+	}
+}
+
+func TestEncodeContext_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, c := range nbtCases {
+		t.Run(c.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := EncodeContext(ctx, buf, c.nbt)
+			assert.Equal(t, &NbtError{Op: "encode", Err: context.Canceled}, err)
+			assert.ErrorIs(t, err, context.Canceled)
+		})
+	}
+}
+
+func TestDecodeOptions_WithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	opts := &DecodeOptions{Lazy: true}
+	actual := opts.WithContext(ctx)
+
+	assert.Equal(t, ctx, actual.Context())
+	assert.True(t, actual.Lazy)
+	assert.Equal(t, context.Background(), opts.Context())
+}
+
+func TestCheckContext_nil(t *testing.T) {
+	var decodeOpts *DecodeOptions
+	assert.NoError(t, decodeOpts.checkContext(0))
+
+	var encodeOpts *EncodeOptions
+	assert.NoError(t, encodeOpts.checkContext(0))
+}
+
+func TestWithContext_nil(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var decodeOpts *DecodeOptions
+	assert.Equal(t, context.Background(), decodeOpts.Context())
+	assert.Equal(t, ctx, decodeOpts.WithContext(ctx).Context())
+
+	var encodeOpts *EncodeOptions
+	assert.Equal(t, context.Background(), encodeOpts.Context())
+	assert.Equal(t, ctx, encodeOpts.WithContext(ctx).Context())
+}
+
+func TestSkipPayload_canceled(t *testing.T) {
+	values := make([]Payload, 0, 2*contextCheckInterval)
+	for i := 0; i < 2*contextCheckInterval; i++ {
+		values = append(values, NewCompoundPayload(NewEndTag()))
+	}
+
+	list := NewListPayload(values...)
+	raw, err := list.AppendNBT(nil, new(EncodeOptions))
+	assert.NoError(t, err)
+
+	compound := NewCompoundPayload(NewListTag(NewTagName("List"), list), NewEndTag())
+	compoundRaw, err := compound.AppendNBT(nil, new(EncodeOptions))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	opts := new(DecodeOptions).WithContext(ctx)
+
+	cases := []struct {
+		name    string
+		payload NbtDecoder
+		raw     []byte
+	}{
+		{
+			name:    `negative case: skipped list`,
+			payload: NewRawPayload(TagTypeList, nil),
+			raw:     raw,
+		},
+		{
+			name:    `negative case: skipped compound`,
+			payload: NewRawPayload(TagTypeCompound, nil),
+			raw:     compoundRaw,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, r := range []io.Reader{bytes.NewReader(tt.raw), newSliceReader(tt.raw, false, nil)} {
+				err := tt.payload.DecodeNBT(r, opts)
+				assert.Equal(t, &NbtError{Op: "decode", Err: context.Canceled}, err)
+			}
+		})
+	}
+
+	err = skipPayload(bytes.NewReader(raw), TagTypeList, nil)
+	assert.NoError(t, err)
+}
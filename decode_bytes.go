@@ -0,0 +1,42 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+// NOTE: returns the number of bytes consumed by the tag, b may hold more data after it
+func DecodeBytes(b []byte) (Tag, int, error) {
+	return DecodeBytesWithOptions(b, new(DecodeOptions))
+}
+
+func DecodeBytesWithOptions(b []byte, opts *DecodeOptions) (Tag, int, error) {
+	if opts == nil {
+		opts = new(DecodeOptions)
+	}
+
+	br := newSliceReader(b, opts.ShareStrings, opts.logger())
+
+	tag, err := decodeTag(br, opts, false)
+	if err != nil {
+		logError(opts.logger(), "failed to decode", "error", err)
+		return nil, 0, err
+	}
+
+	return tag, br.off, nil
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"io"
+	"testing"
+
+	"github.com/Aton-Kish/gonbt/slices"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeBytes(t *testing.T) {
+	type Case struct {
+		name        string
+		raw         []byte
+		expected    Tag
+		expectedErr error
+	}
+
+	cases := []Case{}
+
+	for _, c := range excludeEndTagCases {
+		c := c
+		tag, err := newTagWithPayload(&c.nbt.tagName, c.nbt.payload)
+		assert.NoError(t, err)
+
+		cases = append(cases, Case{
+			name:        c.name,
+			raw:         slices.Concat(c.raw.tagType, c.raw.tagName, c.raw.payload),
+			expected:    tag,
+			expectedErr: nil,
+		})
+	}
+
+	for _, c := range nbtCases {
+		cases = append(cases, Case{
+			name:        c.name,
+			raw:         c.raw,
+			expected:    c.nbt,
+			expectedErr: nil,
+		})
+	}
+
+	cases = append(cases, Case{
+		name:        `negative case: empty`,
+		raw:         []byte{},
+		expected:    nil,
+		expectedErr: &NbtError{Op: "decode", Err: io.EOF},
+	})
+
+	cases = append(cases, Case{
+		name:        `negative case: truncated`,
+		raw:         nbtCases[1].raw[:20],
+		expected:    nil,
+		expectedErr: &NbtError{Op: "decode", Err: io.ErrUnexpectedEOF},
+	})
+
+	// NOTE: lengths beyond the input are rejected before allocating
+	for _, raw := range [][]byte{
+		{0x07, 0x00, 0x00, 0x7F, 0xFF, 0xFF, 0xFF, 0x00, 0x00},
+		{0x09, 0x00, 0x00, 0x03, 0x7F, 0xFF, 0xFF, 0xFF, 0x00},
+		{0x0B, 0x00, 0x00, 0x7F, 0xFF, 0xFF, 0xFF, 0x00, 0x00},
+		{0x0C, 0x00, 0x00, 0x7F, 0xFF, 0xFF, 0xFF, 0x00, 0x00},
+	} {
+		cases = append(cases, Case{
+			name:        `negative case: length beyond input - ` + TagType(raw[0]).String(),
+			raw:         raw,
+			expected:    nil,
+			expectedErr: &NbtError{Op: "decode", Err: io.ErrUnexpectedEOF},
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// NOTE: trailing bytes are left unread
+			raw := tt.raw
+			if tt.expectedErr == nil {
+				raw = slices.Concat(tt.raw, []byte{0xFF, 0xFF})
+			}
+
+			for _, opts := range []*DecodeOptions{{}, {ShareStrings: true}, {Arrays: NewArrayPool()}} {
+				actual, n, err := DecodeBytesWithOptions(raw, opts)
+
+				if tt.expectedErr == nil {
+					assert.NoError(t, err)
+					assert.Equal(t, tt.expected, actual)
+					assert.Equal(t, len(tt.raw), n)
+				} else {
+					assert.Error(t, err)
+					assert.Equal(t, tt.expectedErr, err)
+					assert.Equal(t, 0, n)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeBytesWithOptions_shareStrings(t *testing.T) {
+	raw := slices.Concat(nbtCases[0].raw)
+
+	shared, _, err := DecodeBytesWithOptions(raw, &DecodeOptions{ShareStrings: true})
+	assert.NoError(t, err)
+
+	copied, _, err := DecodeBytes(raw)
+	assert.NoError(t, err)
+
+	// NOTE: "Steve" -> "Steva"
+	raw[len(raw)-3] = 'a'
+
+	assert.Equal(t, `{"Hello World": {Name: "Steva"}}`, Stringify(shared))
+	assert.Equal(t, `{"Hello World": {Name: "Steve"}}`, Stringify(copied))
+}
+
+func TestDecodeBytesWithOptions_lazy(t *testing.T) {
+	for _, c := range nbtCases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, n, err := DecodeBytesWithOptions(c.raw, &DecodeOptions{Lazy: true})
+			assert.NoError(t, err)
+			assert.Equal(t, len(c.raw), n)
+			assert.Equal(t, Stringify(c.nbt), Stringify(actual))
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+// NOTE: r is buffered, so the Decoder may read ahead of the decoded tag, use Decode to stop exactly at its end
+type Decoder struct {
+	br   *byteReader
+	opts *DecodeOptions
+
+	tag Tag
+	err error
+}
+
+func NewDecoder(r io.Reader) *Decoder {
+	return NewDecoderWithOptions(r, new(DecodeOptions))
+}
+
+func NewDecoderWithOptions(r io.Reader, opts *DecodeOptions) *Decoder {
+	return &Decoder{
+		br:   asByteReader(bufio.NewReaderSize(r, bufferSize), opts.logger()),
+		opts: opts,
+	}
+}
+
+// NOTE: returns io.EOF when the stream ends cleanly before the next tag, and io.ErrUnexpectedEOF inside a tag
+func (d *Decoder) Decode() (Tag, error) {
+	tag, err := decodeTag(d.br, d.opts, false)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+
+		logError(d.opts.logger(), "failed to decode", "error", err)
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// NOTE: iterates tags like bufio.Scanner, Err reports nil after a clean end of stream
+func (d *Decoder) Next() bool {
+	if d.err != nil {
+		return false
+	}
+
+	d.tag, d.err = d.Decode()
+
+	return d.err == nil
+}
+
+func (d *Decoder) Tag() Tag {
+	return d.tag
+}
+
+func (d *Decoder) Err() error {
+	if d.err == io.EOF {
+		return nil
+	}
+
+	return d.err
+}
+
+func DecodeAll(r io.Reader) ([]Tag, error) {
+	return DecodeAllWithOptions(r, new(DecodeOptions))
+}
+
+func DecodeAllWithOptions(r io.Reader, opts *DecodeOptions) ([]Tag, error) {
+	d := NewDecoderWithOptions(r, opts)
+
+	tags := []Tag{}
+	for d.Next() {
+		tags = append(tags, d.Tag())
+	}
+
+	if err := d.Err(); err != nil {
+		logError(opts.logger(), "failed to decode", "error", err)
+		return nil, err
+	}
+
+	return tags, nil
+}
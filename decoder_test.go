@@ -0,0 +1,163 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/Aton-Kish/gonbt/slices"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecoder_Decode(t *testing.T) {
+	type Case struct {
+		name        string
+		raw         []byte
+		expected    Tag
+		expectedErr error
+	}
+
+	cases := []Case{}
+
+	for _, c := range nbtCases {
+		cases = append(cases, Case{
+			name:        c.name,
+			raw:         c.raw,
+			expected:    c.nbt,
+			expectedErr: nil,
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// NOTE: the same tag twice in a row
+			buf := bytes.NewBuffer(append(append([]byte{}, tt.raw...), tt.raw...))
+			d := NewDecoder(buf)
+
+			for i := 0; i < 2; i++ {
+				actual, err := d.Decode()
+
+				if tt.expectedErr == nil {
+					assert.NoError(t, err)
+					assert.Equal(t, tt.expected, actual)
+				} else {
+					assert.Error(t, err)
+					assert.Equal(t, tt.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestDecoder_Decode_EOF(t *testing.T) {
+	cases := []struct {
+		name        string
+		raw         []byte
+		expectedErr error
+	}{
+		{
+			name:        `positive case: empty`,
+			raw:         []byte{},
+			expectedErr: io.EOF,
+		},
+		{
+			name:        `negative case: type only`,
+			raw:         []byte{0x0A},
+			expectedErr: &NbtError{Op: "decode", Err: io.ErrUnexpectedEOF},
+		},
+		{
+			name:        `negative case: missing End`,
+			raw:         nbtCases[0].raw[:len(nbtCases[0].raw)-1],
+			expectedErr: &NbtError{Op: "decode", Err: io.ErrUnexpectedEOF},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewDecoder(bytes.NewBuffer(tt.raw)).Decode()
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestDecodeAll(t *testing.T) {
+	cases := []struct {
+		name        string
+		raw         []byte
+		expected    []Tag
+		expectedErr error
+	}{
+		{
+			name:        `positive case: empty`,
+			raw:         []byte{},
+			expected:    []Tag{},
+			expectedErr: nil,
+		},
+		{
+			name:        `positive case: single`,
+			raw:         nbtCases[0].raw,
+			expected:    []Tag{nbtCases[0].nbt},
+			expectedErr: nil,
+		},
+		{
+			name:        `positive case: concatenated`,
+			raw:         slices.Concat(nbtCases[0].raw, nbtCases[1].raw, nbtCases[0].raw),
+			expected:    []Tag{nbtCases[0].nbt, nbtCases[1].nbt, nbtCases[0].nbt},
+			expectedErr: nil,
+		},
+		{
+			name:        `negative case: truncated second tag`,
+			raw:         slices.Concat(nbtCases[0].raw, nbtCases[1].raw[:10]),
+			expected:    nil,
+			expectedErr: &NbtError{Op: "decode", Err: io.ErrUnexpectedEOF},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := DecodeAll(bytes.NewBuffer(tt.raw))
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, actual)
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestDecoder_Next(t *testing.T) {
+	d := NewDecoder(bytes.NewBuffer(slices.Concat(nbtCases[0].raw, nbtCases[1].raw, nbtCases[1].raw[:10])))
+
+	tags := []Tag{}
+	for d.Next() {
+		tags = append(tags, d.Tag())
+	}
+
+	assert.Equal(t, []Tag{nbtCases[0].nbt, nbtCases[1].nbt}, tags)
+	assert.Equal(t, &NbtError{Op: "decode", Err: io.ErrUnexpectedEOF}, d.Err())
+	assert.False(t, d.Next())
+}
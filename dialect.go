@@ -0,0 +1,481 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var unquotedKeyPattern = regexp.MustCompile(`^[0-9A-Za-z._+-]+$`)
+
+type Dialect byte
+
+const (
+	// NOTE: no target, written as Stringify always has
+	DialectDefault Dialect = iota
+	// NOTE: Java Edition before 1.13, text components stored as strings are left as they are
+	DialectPre113
+	// NOTE: Java Edition 1.13 to 1.21.4, text components are JSON strings
+	Dialect113
+	// NOTE: Java Edition 1.21.5 and later, text components are NBT and lists may mix element types
+	Dialect1215
+)
+
+// NOTE: paths of tags holding text components, matched against the end of the path, a trailing [] marks a list of components
+var DefaultTextComponentKeys = []string{
+	"CustomName",
+	"Text1",
+	"Text2",
+	"Text3",
+	"Text4",
+	"custom_name",
+	"display.Lore[]",
+	"display.Name",
+	"filtered_messages[]",
+	"item_name",
+	"lore[]",
+	"messages[]",
+}
+
+func (d Dialect) String() string {
+	switch d {
+	case DialectDefault:
+		return "default"
+	case DialectPre113:
+		return "pre-1.13"
+	case Dialect113:
+		return "1.13"
+	case Dialect1215:
+		return "1.21.5"
+	default:
+		return fmt.Sprintf("Dialect(%d)", byte(d))
+	}
+}
+
+func StringifyDialect(tag Tag, dialect Dialect) (string, error) {
+	return StringifyDialectWithOptions(tag, &StringifyOptions{Space: " ", Dialect: dialect})
+}
+
+// NOTE: converts text components for opts.Dialect and fails with ErrUnsupportedByDialect when a value can't be written in it
+func StringifyDialectWithOptions(tag Tag, opts *StringifyOptions) (string, error) {
+	if opts == nil {
+		opts = new(StringifyOptions)
+	}
+
+	w := &dialectWalker{opts: opts}
+
+	converted, err := w.tag(tag, nil, "")
+	if err != nil {
+		err = &NbtError{Op: "stringify", Err: err}
+		logError(opts.logger(), "failed to stringify", "dialect", opts.Dialect.String(), "error", err)
+		return "", err
+	}
+
+	return StringifyWithOptions(converted, opts), nil
+}
+
+type dialectWalker struct {
+	opts *StringifyOptions
+}
+
+func (w *dialectWalker) unsupported(at string, what string) error {
+	return fmt.Errorf("%s in %s: %w", what, strings.TrimPrefix(at, "."), ErrUnsupportedByDialect)
+}
+
+func (w *dialectWalker) tag(tag Tag, keys []string, at string) (Tag, error) {
+	if tag.TypeId() == TagTypeEnd {
+		return tag, nil
+	}
+
+	name := string(*tag.TagName())
+	keys = append(keys, name)
+	at += "." + name
+
+	payload, err := w.payload(tag.Payload(), keys, at)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTagWithPayload(tag.TagName(), payload)
+}
+
+func (w *dialectWalker) payload(payload Payload, keys []string, at string) (Payload, error) {
+	if raw, ok := payload.(*RawPayload); ok {
+		decoded, err := raw.Decode(new(DecodeOptions))
+		if err != nil {
+			return nil, err
+		}
+
+		payload = decoded
+	}
+
+	if w.opts.Dialect != DialectDefault {
+		if key, ok := w.textKey(keys); ok {
+			if list, ok := payload.(*ListPayload); ok && strings.HasSuffix(key, "[]") {
+				values := make([]Payload, 0, list.Len())
+				for i, elem := range list.Values() {
+					v, err := w.text(elem, fmt.Sprintf("%s[%d]", at, i))
+					if err != nil {
+						return nil, err
+					}
+
+					values = append(values, v)
+				}
+
+				return w.list(NewListPayload(values...), at)
+			} else if !strings.HasSuffix(key, "[]") {
+				return w.text(payload, at)
+			}
+		}
+	}
+
+	switch p := payload.(type) {
+	case *FloatPayload:
+		if f := float64(*p); w.opts.Dialect != DialectDefault && (math.IsNaN(f) || math.IsInf(f, 0)) {
+			return nil, w.unsupported(at, "non-finite float")
+		}
+	case *DoublePayload:
+		if f := float64(*p); w.opts.Dialect != DialectDefault && (math.IsNaN(f) || math.IsInf(f, 0)) {
+			return nil, w.unsupported(at, "non-finite double")
+		}
+	case *ListPayload:
+		values := make([]Payload, 0, p.Len())
+		for i, elem := range p.Values() {
+			v, err := w.payload(elem, keys, fmt.Sprintf("%s[%d]", at, i))
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, v)
+		}
+
+		if p.Len() == 0 {
+			return p, nil
+		}
+
+		return w.list(NewListPayload(values...), at)
+	case *CompoundPayload:
+		tags := make([]Tag, 0, len(*p))
+		for _, tag := range *p {
+			t, err := w.tag(tag, keys, at)
+			if err != nil {
+				return nil, err
+			}
+
+			tags = append(tags, t)
+		}
+
+		return NewCompoundPayload(tags...), nil
+	}
+
+	return payload, nil
+}
+
+func (w *dialectWalker) list(p *ListPayload, at string) (Payload, error) {
+	if p.Heterogeneous() && w.opts.Dialect != DialectDefault && w.opts.Dialect < Dialect1215 {
+		return nil, w.unsupported(at, "heterogeneous list")
+	}
+
+	return p, nil
+}
+
+func (w *dialectWalker) textKey(keys []string) (string, bool) {
+	textKeys := w.opts.TextComponentKeys
+	if textKeys == nil {
+		textKeys = DefaultTextComponentKeys
+	}
+
+	for _, key := range textKeys {
+		path := strings.Split(strings.TrimSuffix(key, "[]"), ".")
+		if len(path) > len(keys) {
+			continue
+		}
+
+		matched := true
+		for i, name := range path {
+			if keys[len(keys)-len(path)+i] != name {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			return key, true
+		}
+	}
+
+	return "", false
+}
+
+// NOTE: moves a text component between its JSON string and NBT forms
+func (w *dialectWalker) text(payload Payload, at string) (Payload, error) {
+	if s, ok := payload.(*StringPayload); ok {
+		switch w.opts.Dialect {
+		case DialectPre113:
+			return s, nil
+		case Dialect113:
+			if json.Valid([]byte(*s)) {
+				return s, nil
+			}
+
+			return NewStringPayload(jsonQuote(string(*s))), nil
+		default:
+			v, ok, err := textFromJSON(string(*s))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", strings.TrimPrefix(at, "."), err)
+			}
+
+			if !ok {
+				return s, nil
+			}
+
+			return w.payload(v, nil, at)
+		}
+	}
+
+	if w.opts.Dialect == Dialect1215 {
+		return w.payload(payload, nil, at)
+	}
+
+	b, err := appendTextJSON(nil, payload)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", strings.TrimPrefix(at, "."), err)
+	}
+
+	return NewStringPayload(string(b)), nil
+}
+
+func jsonQuote(s string) string {
+	buf := new(bytes.Buffer)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// NOTE: bytes holding 0 or 1 are written as booleans, as text components have no other byte fields
+func appendTextJSON(dst []byte, payload Payload) ([]byte, error) {
+	switch p := payload.(type) {
+	case *RawPayload:
+		decoded, err := p.Decode(new(DecodeOptions))
+		if err != nil {
+			return nil, err
+		}
+
+		return appendTextJSON(dst, decoded)
+	case *BytePayload:
+		if b, ok := appendBoolean(dst, int8(*p)); ok {
+			return b, nil
+		}
+
+		return strconv.AppendInt(dst, int64(*p), 10), nil
+	case *ShortPayload:
+		return strconv.AppendInt(dst, int64(*p), 10), nil
+	case *IntPayload:
+		return strconv.AppendInt(dst, int64(*p), 10), nil
+	case *LongPayload:
+		return strconv.AppendInt(dst, int64(*p), 10), nil
+	case *FloatPayload:
+		return appendJSONFloat(dst, float64(*p), 32)
+	case *DoublePayload:
+		return appendJSONFloat(dst, float64(*p), 64)
+	case *StringPayload:
+		return append(dst, jsonQuote(string(*p))...), nil
+	case *ByteArrayPayload:
+		dst = append(dst, '[')
+		for i, v := range *p {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+
+			dst = strconv.AppendInt(dst, int64(v), 10)
+		}
+
+		return append(dst, ']'), nil
+	case *IntArrayPayload:
+		dst = append(dst, '[')
+		for i, v := range *p {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+
+			dst = strconv.AppendInt(dst, int64(v), 10)
+		}
+
+		return append(dst, ']'), nil
+	case *LongArrayPayload:
+		dst = append(dst, '[')
+		for i, v := range *p {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+
+			dst = strconv.AppendInt(dst, v, 10)
+		}
+
+		return append(dst, ']'), nil
+	case *ListPayload:
+		dst = append(dst, '[')
+		for i, elem := range p.Values() {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+
+			var err error
+			if dst, err = appendTextJSON(dst, elem); err != nil {
+				return nil, err
+			}
+		}
+
+		return append(dst, ']'), nil
+	case *CompoundPayload:
+		dst = append(dst, '{')
+		for i, tag := range *p {
+			if tag.TypeId() == TagTypeEnd {
+				break
+			}
+
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+
+			dst = append(dst, jsonQuote(string(*tag.TagName()))...)
+			dst = append(dst, ':')
+
+			var err error
+			if dst, err = appendTextJSON(dst, tag.Payload()); err != nil {
+				return nil, err
+			}
+		}
+
+		return append(dst, '}'), nil
+	default:
+		return nil, ErrInvalidTagType
+	}
+}
+
+func appendJSONFloat(dst []byte, f float64, bitSize int) ([]byte, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, fmt.Errorf("non-finite number: %w", ErrUnsupportedByDialect)
+	}
+
+	return strconv.AppendFloat(dst, f, 'g', -1, bitSize), nil
+}
+
+// NOTE: reports false unless s holds a JSON object, array or string
+func textFromJSON(s string) (Payload, bool, error) {
+	t := strings.TrimSpace(s)
+	if t == "" || !strings.ContainsRune(`{["`, rune(t[0])) || !json.Valid([]byte(t)) {
+		return nil, false, nil
+	}
+
+	d := json.NewDecoder(strings.NewReader(t))
+	d.UseNumber()
+
+	v, err := jsonToPayload(d)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return v, true, nil
+}
+
+func jsonToPayload(d *json.Decoder) (Payload, error) {
+	tok, err := d.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := tok.(type) {
+	case json.Delim:
+		if v == '[' {
+			values := []Payload{}
+			for d.More() {
+				elem, err := jsonToPayload(d)
+				if err != nil {
+					return nil, err
+				}
+
+				values = append(values, elem)
+			}
+
+			if _, err := d.Token(); err != nil {
+				return nil, err
+			}
+
+			return NewListPayload(values...), nil
+		}
+
+		tags := []Tag{}
+		for d.More() {
+			key, err := d.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := jsonToPayload(d)
+			if err != nil {
+				return nil, err
+			}
+
+			tag, err := newTagWithPayload(NewTagName(key.(string)), value)
+			if err != nil {
+				return nil, err
+			}
+
+			tags = append(tags, tag)
+		}
+
+		if _, err := d.Token(); err != nil {
+			return nil, err
+		}
+
+		return NewCompoundPayload(append(tags, NewEndTag())...), nil
+	case string:
+		return NewStringPayload(v), nil
+	case bool:
+		return boolPayload(v), nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			if i >= math.MinInt32 && i <= math.MaxInt32 {
+				return NewIntPayload(int32(i)), nil
+			}
+
+			return NewLongPayload(i), nil
+		}
+
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+
+		return NewDoublePayload(f), nil
+	default:
+		return nil, fmt.Errorf("null: %w", ErrUnsupportedByDialect)
+	}
+}
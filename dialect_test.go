@@ -0,0 +1,175 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringifyDialect(t *testing.T) {
+	item := NewCompoundTag(NewTagName(``), NewCompoundPayload(
+		NewStringTag(NewTagName(`id`), NewStringPayload(`minecraft:stick`)),
+		NewCompoundTag(NewTagName(`tag`), NewCompoundPayload(
+			NewCompoundTag(NewTagName(`display`), NewCompoundPayload(
+				NewStringTag(NewTagName(`Name`), NewStringPayload(`{"text":"Wand","italic":false}`)),
+				NewListTag(NewTagName(`Lore`), NewListPayload(NewStringPayload(`Old "magic"`))),
+				NewEndTag(),
+			)),
+			NewStringTag(NewTagName(`note`), NewStringPayload("it's\n")),
+			NewEndTag(),
+		)),
+		NewEndTag(),
+	))
+
+	cases := []struct {
+		name     string
+		tag      Tag
+		dialect  Dialect
+		expected string
+	}{
+		{
+			name:     `positive case: pre-1.13`,
+			tag:      item,
+			dialect:  DialectPre113,
+			expected: `{id: "minecraft:stick", tag: {display: {Lore: ["Old \"magic\""], Name: "{\"text\":\"Wand\",\"italic\":false}"}, note: "it's` + "\n" + `"}}`,
+		},
+		{
+			name:     `positive case: 1.13`,
+			tag:      item,
+			dialect:  Dialect113,
+			expected: `{id: "minecraft:stick", tag: {display: {Lore: ["\"Old \\\"magic\\\"\""], Name: "{\"text\":\"Wand\",\"italic\":false}"}, note: "it's` + "\n" + `"}}`,
+		},
+		{
+			name:     `positive case: 1.21.5`,
+			tag:      item,
+			dialect:  Dialect1215,
+			expected: `{id: "minecraft:stick", tag: {display: {Lore: ['Old "magic"'], Name: {italic: 0b, text: "Wand"}}, note: "it's\n"}}`,
+		},
+		{
+			name: `positive case: nbt text component to json`,
+			tag: NewCompoundTag(NewTagName(``), NewCompoundPayload(
+				NewCompoundTag(NewTagName(`CustomName`), NewCompoundPayload(
+					NewStringTag(NewTagName(`text`), NewStringPayload(`Bob`)),
+					NewByteTag(NewTagName(`bold`), NewBytePayload(1)),
+					NewListTag(NewTagName(`extra`), NewListPayload(NewStringPayload(`!`))),
+					NewEndTag(),
+				)),
+				NewEndTag(),
+			)),
+			dialect:  Dialect113,
+			expected: `{CustomName: "{\"text\":\"Bob\",\"bold\":true,\"extra\":[\"!\"]}"}`,
+		},
+		{
+			name: `positive case: quoted keys`,
+			tag: NewCompoundTag(NewTagName(`root`), NewCompoundPayload(
+				NewIntTag(NewTagName(``), NewIntPayload(1)),
+				NewIntTag(NewTagName(`a b`), NewIntPayload(2)),
+				NewIntTag(NewTagName(`ü`), NewIntPayload(3)),
+				NewEndTag(),
+			)),
+			dialect:  Dialect113,
+			expected: `{root: {"": 1, "a b": 2, "ü": 3}}`,
+		},
+		{
+			name: `positive case: heterogeneous list`,
+			tag: NewCompoundTag(NewTagName(``), NewCompoundPayload(
+				NewListTag(NewTagName(`l`), NewListPayload(NewIntPayload(1), NewStringPayload(`a`))),
+				NewEndTag(),
+			)),
+			dialect:  Dialect1215,
+			expected: `{l: [1, "a"]}`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := StringifyDialect(tt.tag, tt.dialect)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestStringifyDialect_unsupported(t *testing.T) {
+	cases := []struct {
+		name    string
+		tag     Tag
+		dialect Dialect
+	}{
+		{
+			name: `negative case: heterogeneous list before 1.21.5`,
+			tag: NewCompoundTag(NewTagName(``), NewCompoundPayload(
+				NewListTag(NewTagName(`l`), NewListPayload(NewIntPayload(1), NewStringPayload(`a`))),
+				NewEndTag(),
+			)),
+			dialect: Dialect113,
+		},
+		{
+			name: `negative case: NaN`,
+			tag: NewCompoundTag(NewTagName(``), NewCompoundPayload(
+				NewDoubleTag(NewTagName(`d`), NewDoublePayload(math.NaN())),
+				NewEndTag(),
+			)),
+			dialect: Dialect1215,
+		},
+		{
+			name: `negative case: infinity in a text component`,
+			tag: NewCompoundTag(NewTagName(``), NewCompoundPayload(
+				NewCompoundTag(NewTagName(`CustomName`), NewCompoundPayload(
+					NewFloatTag(NewTagName(`x`), NewFloatPayload(float32(math.Inf(1)))),
+					NewEndTag(),
+				)),
+				NewEndTag(),
+			)),
+			dialect: DialectPre113,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := StringifyDialect(tt.tag, tt.dialect)
+			assert.True(t, errors.Is(err, ErrUnsupportedByDialect))
+		})
+	}
+}
+
+func TestStringifyDialect_reversible(t *testing.T) {
+	tag := NewCompoundTag(NewTagName(``), NewCompoundPayload(
+		NewStringTag(NewTagName(`CustomName`), NewStringPayload(`{"text":"a\\b"}`)),
+		NewStringTag(NewTagName(`s`), NewStringPayload(`"q" \ 'p'`)),
+		NewEndTag(),
+	))
+
+	for _, dialect := range []Dialect{DialectDefault, DialectPre113, Dialect113} {
+		t.Run(dialect.String(), func(t *testing.T) {
+			snbt, err := StringifyDialect(tag, dialect)
+			assert.NoError(t, err)
+
+			actual, err := Parse(snbt)
+			assert.NoError(t, err)
+			assert.Equal(t, tag, actual)
+		})
+	}
+}
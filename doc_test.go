@@ -23,6 +23,7 @@ package nbt_test
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"log"
 
 	nbt "github.com/Aton-Kish/gonbt"
@@ -412,3 +413,220 @@ func ExamplePrettyJson_tab() {
 	// 	}
 	// }
 }
+
+func ExampleListOf() {
+	pos := nbt.NewListTag(nbt.NewTagName("Pos"), nbt.ListOf(0.5, 64.0, -12.25))
+
+	fmt.Println(nbt.Stringify(pos))
+	// Output:
+	// {Pos: [0.5d, 64d, -12.25d]}
+}
+
+func ExampleAsSlice() {
+	pos := nbt.NewListPayload(nbt.NewDoublePayload(0.5), nbt.NewDoublePayload(64), nbt.NewDoublePayload(-12.25))
+
+	values, err := nbt.AsSlice[float64](pos)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(values)
+	// Output:
+	// [0.5 64 -12.25]
+}
+
+func ExampleDecodeWithOptions_lazy() {
+	// fake NBT: {Data: {Player: {Pos: [0.5d, 64d, -12.25d]}}}
+	r := bytes.NewBuffer([]byte{
+		0x0A, 0x00, 0x00,
+		0x0A, 0x00, 0x04, 0x44, 0x61, 0x74, 0x61,
+		0x0A, 0x00, 0x06, 0x50, 0x6C, 0x61, 0x79, 0x65, 0x72,
+		0x09, 0x00, 0x03, 0x50, 0x6F, 0x73,
+		0x06, 0x00, 0x00, 0x00, 0x03,
+		0x3F, 0xE0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x40, 0x50, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0xC0, 0x28, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00,
+		0x00,
+		0x00,
+	})
+
+	opts := &nbt.DecodeOptions{Lazy: true}
+
+	root, err := nbt.DecodeWithOptions(r, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// NOTE: nested compounds are kept undecoded until they are needed
+	var tag nbt.Tag = root
+	for _, name := range []string{"Data", "Player", "Pos"} {
+		for _, child := range *tag.Payload().(*nbt.CompoundPayload) {
+			if child.TagName() == nil || string(*child.TagName()) != name {
+				continue
+			}
+
+			raw, ok := child.(*nbt.RawTag)
+			if !ok {
+				log.Fatal("unexpected tag")
+			}
+
+			tag, err = raw.Decode(opts)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			break
+		}
+	}
+
+	pos, err := nbt.AsSlice[float64](tag.Payload().(*nbt.ListPayload))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(pos)
+	// Output:
+	// [0.5 64 -12.25]
+}
+
+func ExampleDecodeSelect() {
+	// fake NBT: {Data: {Player: {Health: 20f, Pos: [0.5d, 64d, -12.25d]}, Version: 19133}}
+	r := bytes.NewBuffer([]byte{
+		0x0A, 0x00, 0x00,
+		0x0A, 0x00, 0x04, 0x44, 0x61, 0x74, 0x61,
+		0x0A, 0x00, 0x06, 0x50, 0x6C, 0x61, 0x79, 0x65, 0x72,
+		0x05, 0x00, 0x06, 0x48, 0x65, 0x61, 0x6C, 0x74, 0x68,
+		0x41, 0xA0, 0x00, 0x00,
+		0x09, 0x00, 0x03, 0x50, 0x6F, 0x73,
+		0x06, 0x00, 0x00, 0x00, 0x03,
+		0x3F, 0xE0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x40, 0x50, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0xC0, 0x28, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00,
+		0x03, 0x00, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6F, 0x6E,
+		0x00, 0x00, 0x4A, 0xBD,
+		0x00,
+		0x00,
+	})
+
+	tag, err := nbt.DecodeSelect(r, "Data.Player.Pos")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(nbt.Stringify(tag))
+	// Output:
+	// {Data: {Player: {Pos: [0.5d, 64d, -12.25d]}}}
+}
+
+func ExampleReader() {
+	// fake NBT: {Level: {Heightmap: [L; 1L, 2L, 3L]}}
+	r := nbt.NewReaderSize(bytes.NewBuffer([]byte{
+		0x0A, 0x00, 0x05, 0x4C, 0x65, 0x76, 0x65, 0x6C,
+		0x0C, 0x00, 0x09, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x6D, 0x61, 0x70,
+		0x00, 0x00, 0x00, 0x03,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03,
+		0x00,
+	}), 2)
+
+	for {
+		ev, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		switch ev.Kind {
+		case nbt.EventArrayChunk:
+			fmt.Println(ev.Kind, ev.Value)
+		case nbt.EventEnd:
+			fmt.Println(ev.Kind, ev.Type)
+		default:
+			fmt.Println(ev.Kind, ev.Type, ev.Name)
+		}
+	}
+
+	// Output:
+	// BeginCompound Compound Level
+	// BeginArray LongArray Heightmap
+	// ArrayChunk [L; 1L, 2L]
+	// ArrayChunk [L; 3L]
+	// End LongArray
+	// End Compound
+}
+
+func ExampleWriter() {
+	buf := new(bytes.Buffer)
+	w := nbt.NewWriter(buf)
+
+	if err := w.BeginCompound(""); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := w.BeginList("Pos", nbt.TagTypeDouble, 3); err != nil {
+		log.Fatal(err)
+	}
+
+	for _, v := range []float64{0.5, 64, -12.25} {
+		if err := w.Double("", v); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if err := w.End(); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := w.Int("DataVersion", 3465); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := w.End(); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := w.Close(); err != nil {
+		log.Fatal(err)
+	}
+
+	tag, err := nbt.Decode(buf)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(nbt.Stringify(tag))
+	// Output:
+	// {DataVersion: 3465, Pos: [0.5d, 64d, -12.25d]}
+}
+
+func ExampleDecoder_Next() {
+	// fake NBT: {id: "minecraft:chest"} {id: "minecraft:furnace"}
+	r := bytes.NewBuffer([]byte{
+		0x0A, 0x00, 0x00,
+		0x08, 0x00, 0x02, 0x69, 0x64,
+		0x00, 0x0F, 0x6D, 0x69, 0x6E, 0x65, 0x63, 0x72, 0x61, 0x66, 0x74, 0x3A, 0x63, 0x68, 0x65, 0x73, 0x74,
+		0x00,
+		0x0A, 0x00, 0x00,
+		0x08, 0x00, 0x02, 0x69, 0x64,
+		0x00, 0x11, 0x6D, 0x69, 0x6E, 0x65, 0x63, 0x72, 0x61, 0x66, 0x74, 0x3A, 0x66, 0x75, 0x72, 0x6E, 0x61, 0x63, 0x65,
+		0x00,
+	})
+
+	d := nbt.NewDecoder(r)
+	for d.Next() {
+		fmt.Println(nbt.Stringify(d.Tag()))
+	}
+
+	if err := d.Err(); err != nil {
+		log.Fatal(err)
+	}
+
+	// Output:
+	// {id: "minecraft:chest"}
+	// {id: "minecraft:furnace"}
+}
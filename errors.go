@@ -26,9 +26,14 @@ import (
 )
 
 var (
-	ErrInvalidTagType    = errors.New("invalid tag type")
-	ErrInvalidSnbtFormat = errors.New("invalid snbt format")
-	ErrDecode            = errors.New("failed to decode")
+	ErrInvalidTagType       = errors.New("invalid tag type")
+	ErrInvalidSnbtFormat    = errors.New("invalid snbt format")
+	ErrDecode               = errors.New("failed to decode")
+	ErrInvalidElementType   = errors.New("invalid element type")
+	ErrInvalidNesting       = errors.New("invalid nesting")
+	ErrOutOfRange           = errors.New("out of range")
+	ErrUnsupportedByDialect = errors.New("unsupported by dialect")
+	ErrNonFinite            = errors.New("non-finite number")
 )
 
 type NbtError struct {
@@ -54,3 +59,20 @@ func (e *NbtError) Error() string {
 func (e *NbtError) Unwrap() error {
 	return e.Err
 }
+
+// NOTE: tells what the parser expected, errors.Is still matches ErrInvalidSnbtFormat
+type syntaxError struct {
+	msg string
+}
+
+func expected(format string, args ...any) error {
+	return &syntaxError{msg: "expected " + fmt.Sprintf(format, args...)}
+}
+
+func (e *syntaxError) Error() string {
+	return e.msg
+}
+
+func (e *syntaxError) Is(target error) bool {
+	return target == ErrInvalidSnbtFormat
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringifyWithOptions_format(t *testing.T) {
+	tag := NewCompoundTag(NewTagName(``), NewCompoundPayload(
+		NewStringTag(NewTagName(`name`), NewStringPayload(`it's`)),
+		NewListTag(NewTagName(`Pos`), NewListPayload(NewDoublePayload(1.5), NewDoublePayload(64), NewDoublePayload(-2))),
+		NewIntArrayTag(NewTagName(`UUID`), NewIntArrayPayload(1, 2, 3, 4, 5)),
+		NewByteTag(NewTagName(`a-b`), NewBytePayload(1)),
+		NewByteTag(NewTagName(`a`), NewBytePayload(0)),
+		NewEndTag(),
+	))
+
+	cases := []struct {
+		name     string
+		opts     *StringifyOptions
+		expected string
+	}{
+		{
+			name:     `positive case: lexicographic`,
+			opts:     &StringifyOptions{Space: " "},
+			expected: `{Pos: [1.5d, 64d, -2d], UUID: [I; 1, 2, 3, 4, 5], a-b: 1b, a: 0b, name: "it's"}`,
+		},
+		{
+			name:     `positive case: insertion`,
+			opts:     &StringifyOptions{Space: " ", KeyOrder: KeyOrderInsertion},
+			expected: `{name: "it's", Pos: [1.5d, 64d, -2d], UUID: [I; 1, 2, 3, 4, 5], a-b: 1b, a: 0b}`,
+		},
+		{
+			name:     `positive case: by type`,
+			opts:     &StringifyOptions{Space: " ", KeyOrder: KeyOrderType},
+			expected: `{a-b: 1b, a: 0b, name: "it's", Pos: [1.5d, 64d, -2d], UUID: [I; 1, 2, 3, 4, 5]}`,
+		},
+		{
+			name:     `positive case: prefer single quotes`,
+			opts:     &StringifyOptions{Space: " ", KeyOrder: KeyOrderInsertion, QuoteStyle: QuotePreferSingle},
+			expected: `{name: "it's", Pos: [1.5d, 64d, -2d], UUID: [I; 1, 2, 3, 4, 5], a-b: 1b, a: 0b}`,
+		},
+		{
+			name:     `positive case: trailing newline`,
+			opts:     &StringifyOptions{KeyOrder: KeyOrderInsertion, TrailingNewline: true},
+			expected: "{name:\"it's\",Pos:[1.5d,64d,-2d],UUID:[I;1,2,3,4,5],a-b:1b,a:0b}\n",
+		},
+		{
+			name: `positive case: pretty`,
+			opts: &StringifyOptions{Space: " ", Indent: "  ", KeyOrder: KeyOrderInsertion},
+			expected: `{
+  name: "it's",
+  Pos: [
+    1.5d,
+    64d,
+    -2d
+  ],
+  UUID: [I; 1, 2, 3, 4, 5],
+  a-b: 1b,
+  a: 0b
+}`,
+		},
+		{
+			name: `positive case: collapse`,
+			opts: &StringifyOptions{Space: " ", Indent: "  ", KeyOrder: KeyOrderInsertion, CollapseWidth: 20, ArrayWrap: 2},
+			expected: `{
+  name: "it's",
+  Pos: [1.5d, 64d, -2d],
+  UUID: [I; 1, 2, 3, 4, 5],
+  a-b: 1b,
+  a: 0b
+}`,
+		},
+		{
+			name: `positive case: array wrap`,
+			opts: &StringifyOptions{Space: " ", Indent: "  ", KeyOrder: KeyOrderInsertion, CollapseWidth: 16, ArrayWrap: 2},
+			expected: `{
+  name: "it's",
+  Pos: [1.5d, 64d, -2d],
+  UUID: [I;
+    1, 2,
+    3, 4,
+    5
+  ],
+  a-b: 1b,
+  a: 0b
+}`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := StringifyWithOptions(tag, tt.opts)
+			assert.Equal(t, tt.expected, actual)
+
+			parsed, err := Parse(actual)
+			assert.NoError(t, err)
+			assert.Equal(t, Stringify(tag), Stringify(parsed))
+		})
+	}
+}
+
+func TestStringifyWithOptions_quoteStyle(t *testing.T) {
+	cases := []struct {
+		name     string
+		s        string
+		style    QuoteStyle
+		expected string
+	}{
+		{name: `positive case: double`, s: `a`, style: QuotePreferDouble, expected: `"a"`},
+		{name: `positive case: double with double quote`, s: `"a"`, style: QuotePreferDouble, expected: `'"a"'`},
+		{name: `positive case: single`, s: `a`, style: QuotePreferSingle, expected: `'a'`},
+		{name: `positive case: single with single quote`, s: `it's`, style: QuotePreferSingle, expected: `"it's"`},
+		{name: `positive case: single with both`, s: `it's "a"`, style: QuotePreferSingle, expected: `'it\'s "a"'`},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			tag := NewStringTag(NewTagName(`k y`), NewStringPayload(tt.s))
+			actual := StringifyWithOptions(tag, &StringifyOptions{QuoteStyle: tt.style})
+
+			name := `"k y"`
+			if tt.style == QuotePreferSingle {
+				name = `'k y'`
+			}
+
+			assert.Equal(t, `{`+name+`:`+tt.expected+`}`, actual)
+
+			parsed, err := Parse(actual)
+			assert.NoError(t, err)
+			assert.Equal(t, NewCompoundTag(NewTagName(``), NewCompoundPayload(tag, NewEndTag())), parsed)
+		})
+	}
+}
@@ -1,11 +1,12 @@
 module github.com/Aton-Kish/gonbt
 
-go 1.19
+go 1.21
 
 require (
 	github.com/iancoleman/strcase v0.2.0
 	github.com/stretchr/testify v1.8.0
 	golang.org/x/exp v0.0.0-20220921164117-439092de6870
+	golang.org/x/text v0.7.0
 	golang.org/x/tools v0.1.12
 )
 
@@ -0,0 +1,215 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+type ListElement interface {
+	int8 | int16 | int32 | int64 | float32 | float64 | []int8 | string | []int32 | []int64
+}
+
+func ListOf[T ListElement](values ...T) *ListPayload {
+	if len(values) == 0 {
+		var zero T
+		return NewEmptyListPayload(payloadOf(zero).TypeId())
+	}
+
+	payloads := make([]Payload, 0, len(values))
+	for _, v := range values {
+		payloads = append(payloads, payloadOf(v))
+	}
+
+	return NewListPayload(payloads...)
+}
+
+func ListOfPayload[T Payload](values ...T) *ListPayload {
+	if len(values) == 0 {
+		var zero T
+		if any(zero) == nil {
+			return NewListPayload()
+		}
+
+		return NewEmptyListPayload(zero.TypeId())
+	}
+
+	payloads := make([]Payload, 0, len(values))
+	for _, v := range values {
+		payloads = append(payloads, v)
+	}
+
+	return NewListPayload(payloads...)
+}
+
+func AsSlice[T ListElement](list *ListPayload) ([]T, error) {
+	if list == nil {
+		return nil, nil
+	}
+
+	values := make([]T, 0, list.Len())
+	for _, payload := range list.values {
+		v, ok := valueOf(payload).(T)
+		if !ok {
+			err := &NbtError{Op: "convert", Err: ErrInvalidElementType}
+			logError(nil, "failed to convert", "payload", list, "error", err)
+			return nil, err
+		}
+
+		values = append(values, v)
+	}
+
+	return values, nil
+}
+
+func AsPayloadSlice[T Payload](list *ListPayload) ([]T, error) {
+	if list == nil {
+		return nil, nil
+	}
+
+	values := make([]T, 0, list.Len())
+	for _, payload := range list.values {
+		v, ok := payload.(T)
+		if !ok {
+			err := &NbtError{Op: "convert", Err: ErrInvalidElementType}
+			logError(nil, "failed to convert", "payload", list, "error", err)
+			return nil, err
+		}
+
+		values = append(values, v)
+	}
+
+	return values, nil
+}
+
+func payloadOf(value any) Payload {
+	switch v := value.(type) {
+	case int8:
+		return NewBytePayload(v)
+	case int16:
+		return NewShortPayload(v)
+	case int32:
+		return NewIntPayload(v)
+	case int64:
+		return NewLongPayload(v)
+	case float32:
+		return NewFloatPayload(v)
+	case float64:
+		return NewDoublePayload(v)
+	case []int8:
+		return NewByteArrayPayload(v...)
+	case string:
+		return NewStringPayload(v)
+	case []int32:
+		return NewIntArrayPayload(v...)
+	case []int64:
+		return NewLongArrayPayload(v...)
+	default:
+		return nil
+	}
+}
+
+func valueOf(payload Payload) any {
+	switch p := payload.(type) {
+	case *BytePayload:
+		return int8(*p)
+	case *ShortPayload:
+		return int16(*p)
+	case *IntPayload:
+		return int32(*p)
+	case *LongPayload:
+		return int64(*p)
+	case *FloatPayload:
+		return float32(*p)
+	case *DoublePayload:
+		return float64(*p)
+	case *ByteArrayPayload:
+		return []int8(*p)
+	case *StringPayload:
+		return string(*p)
+	case *IntArrayPayload:
+		return []int32(*p)
+	case *LongArrayPayload:
+		return []int64(*p)
+	default:
+		return nil
+	}
+}
+
+// NOTE: heterogeneous lists are encoded as lists of compounds wrapping each element under an empty name, as 1.21.5 does
+func (p *ListPayload) Heterogeneous() bool {
+	for _, payload := range p.values {
+		if payload.TypeId() != p.values[0].TypeId() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NOTE: only heterogeneous lists and lists unwrapped on decode are wrapped, compounds that are not a wrapper are kept as is, p itself is returned on error
+func (p *ListPayload) wrapped() (*ListPayload, error) {
+	if !p.Heterogeneous() && !p.unwrapped {
+		return p, nil
+	}
+
+	values := make([]Payload, 0, len(p.values))
+	for _, payload := range p.values {
+		if c, ok := payload.(*CompoundPayload); ok && !c.isWrapper() {
+			values = append(values, c)
+			continue
+		}
+
+		var tag Tag
+		if raw, ok := payload.(*RawPayload); ok {
+			tag = NewRawTag(NewTagName(""), raw)
+		} else {
+			var err error
+			if tag, err = newTagWithPayload(NewTagName(""), payload); err != nil {
+				return p, err
+			}
+		}
+
+		values = append(values, NewCompoundPayload(tag, NewEndTag()))
+	}
+
+	return NewListPayload(values...), nil
+}
+
+// NOTE: lazily decoded compounds are kept as is, a list left homogeneous is marked so that it is wrapped again on encode
+func (p *ListPayload) unwrap() {
+	n := 0
+	for i, payload := range p.values {
+		if c, ok := payload.(*CompoundPayload); ok && c.isWrapper() {
+			p.values[i] = (*c)[0].Payload()
+			n++
+		}
+	}
+
+	p.unwrapped = n > 0 && !p.Heterogeneous()
+}
+
+func (p *CompoundPayload) isWrapper() bool {
+	n := 0
+	for _, tag := range *p {
+		if tag.TypeId() != TagTypeEnd {
+			n++
+		}
+	}
+
+	return n == 1 && len(*p) > 0 && (*p)[0].TypeId() != TagTypeEnd && *(*p)[0].TagName() == ""
+}
@@ -0,0 +1,500 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListOf_int32(t *testing.T) {
+	cases := []struct {
+		name     string
+		values   []int32
+		expected *ListPayload
+	}{
+		{
+			name:     `positive case: has items`,
+			values:   []int32{123, 456},
+			expected: NewListPayload(NewIntPayload(123), NewIntPayload(456)),
+		},
+		{
+			name:     `positive case: empty`,
+			values:   []int32{},
+			expected: NewEmptyListPayload(TagTypeInt),
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := ListOf(tt.values...)
+			assert.Equal(t, tt.expected, actual)
+			assert.Equal(t, tt.expected.ElemType(), actual.ElemType())
+		})
+	}
+}
+
+func TestListOf_float64(t *testing.T) {
+	cases := []struct {
+		name     string
+		values   []float64
+		expected *ListPayload
+	}{
+		{
+			name:     `positive case: has items`,
+			values:   []float64{0.5, 64, -12.25},
+			expected: NewListPayload(NewDoublePayload(0.5), NewDoublePayload(64), NewDoublePayload(-12.25)),
+		},
+		{
+			name:     `positive case: empty`,
+			values:   []float64{},
+			expected: NewEmptyListPayload(TagTypeDouble),
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := ListOf(tt.values...)
+			assert.Equal(t, tt.expected, actual)
+			assert.Equal(t, tt.expected.ElemType(), actual.ElemType())
+		})
+	}
+}
+
+func TestListOf_string(t *testing.T) {
+	cases := []struct {
+		name     string
+		values   []string
+		expected *ListPayload
+	}{
+		{
+			name:     `positive case: has items`,
+			values:   []string{"Hello", "World"},
+			expected: NewListPayload(NewStringPayload("Hello"), NewStringPayload("World")),
+		},
+		{
+			name:     `positive case: empty`,
+			values:   []string{},
+			expected: NewEmptyListPayload(TagTypeString),
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := ListOf(tt.values...)
+			assert.Equal(t, tt.expected, actual)
+			assert.Equal(t, tt.expected.ElemType(), actual.ElemType())
+		})
+	}
+}
+
+func TestListOf_longArray(t *testing.T) {
+	cases := []struct {
+		name     string
+		values   [][]int64
+		expected *ListPayload
+	}{
+		{
+			name:     `positive case: has items`,
+			values:   [][]int64{{0, 1}, {}},
+			expected: NewListPayload(NewLongArrayPayload(0, 1), NewLongArrayPayload()),
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := ListOf(tt.values...)
+			assert.Equal(t, tt.expected, actual)
+			assert.Equal(t, tt.expected.ElemType(), actual.ElemType())
+		})
+	}
+}
+
+func TestListOfPayload(t *testing.T) {
+	cases := []struct {
+		name     string
+		values   []*CompoundPayload
+		expected *ListPayload
+	}{
+		{
+			name: `positive case: has items`,
+			values: []*CompoundPayload{
+				NewCompoundPayload(NewStringTag(NewTagName(`Name`), NewStringPayload(`Steve`)), NewEndTag()),
+				NewCompoundPayload(NewEndTag()),
+			},
+			expected: NewListPayload(
+				NewCompoundPayload(NewStringTag(NewTagName(`Name`), NewStringPayload(`Steve`)), NewEndTag()),
+				NewCompoundPayload(NewEndTag()),
+			),
+		},
+		{
+			name:     `positive case: empty`,
+			values:   []*CompoundPayload{},
+			expected: NewEmptyListPayload(TagTypeCompound),
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := ListOfPayload(tt.values...)
+			assert.Equal(t, tt.expected, actual)
+			assert.Equal(t, tt.expected.ElemType(), actual.ElemType())
+		})
+	}
+}
+
+func TestAsSlice_int32(t *testing.T) {
+	cases := []struct {
+		name        string
+		list        *ListPayload
+		expected    []int32
+		expectedErr error
+	}{
+		{
+			name:        `positive case: has items`,
+			list:        NewListPayload(NewIntPayload(123), NewIntPayload(456)),
+			expected:    []int32{123, 456},
+			expectedErr: nil,
+		},
+		{
+			name:        `positive case: empty`,
+			list:        NewListPayload(),
+			expected:    []int32{},
+			expectedErr: nil,
+		},
+		{
+			name:        `positive case: nil`,
+			list:        nil,
+			expected:    nil,
+			expectedErr: nil,
+		},
+		{
+			name:        `negative case: Short`,
+			list:        NewListPayload(NewShortPayload(123)),
+			expected:    nil,
+			expectedErr: &NbtError{Op: "convert", Err: ErrInvalidElementType},
+		},
+		{
+			name:        `negative case: mixed`,
+			list:        NewListPayload(NewIntPayload(123), NewLongPayload(456)),
+			expected:    nil,
+			expectedErr: &NbtError{Op: "convert", Err: ErrInvalidElementType},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := AsSlice[int32](tt.list)
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, actual)
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestAsSlice_float64(t *testing.T) {
+	cases := []struct {
+		name        string
+		list        *ListPayload
+		expected    []float64
+		expectedErr error
+	}{
+		{
+			name:        `positive case: has items`,
+			list:        NewListPayload(NewDoublePayload(0.5), NewDoublePayload(64), NewDoublePayload(-12.25)),
+			expected:    []float64{0.5, 64, -12.25},
+			expectedErr: nil,
+		},
+		{
+			name:        `negative case: Float`,
+			list:        NewListPayload(NewFloatPayload(0.5)),
+			expected:    nil,
+			expectedErr: &NbtError{Op: "convert", Err: ErrInvalidElementType},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := AsSlice[float64](tt.list)
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, actual)
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestAsSlice_string(t *testing.T) {
+	cases := []struct {
+		name        string
+		list        *ListPayload
+		expected    []string
+		expectedErr error
+	}{
+		{
+			name:        `positive case: has items`,
+			list:        NewListPayload(NewStringPayload("Hello"), NewStringPayload("World")),
+			expected:    []string{"Hello", "World"},
+			expectedErr: nil,
+		},
+		{
+			name:        `negative case: List`,
+			list:        NewListPayload(NewListPayload()),
+			expected:    nil,
+			expectedErr: &NbtError{Op: "convert", Err: ErrInvalidElementType},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := AsSlice[string](tt.list)
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, actual)
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestAsPayloadSlice(t *testing.T) {
+	cases := []struct {
+		name        string
+		list        *ListPayload
+		expected    []*CompoundPayload
+		expectedErr error
+	}{
+		{
+			name: `positive case: has items`,
+			list: NewListPayload(
+				NewCompoundPayload(NewStringTag(NewTagName(`Name`), NewStringPayload(`Steve`)), NewEndTag()),
+				NewCompoundPayload(NewEndTag()),
+			),
+			expected: []*CompoundPayload{
+				NewCompoundPayload(NewStringTag(NewTagName(`Name`), NewStringPayload(`Steve`)), NewEndTag()),
+				NewCompoundPayload(NewEndTag()),
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        `positive case: nil`,
+			list:        nil,
+			expected:    nil,
+			expectedErr: nil,
+		},
+		{
+			name:        `negative case: String`,
+			list:        NewListPayload(NewStringPayload("Steve")),
+			expected:    nil,
+			expectedErr: &NbtError{Op: "convert", Err: ErrInvalidElementType},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := AsPayloadSlice[*CompoundPayload](tt.list)
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, actual)
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestListPayload_heterogeneous(t *testing.T) {
+	cases := []struct {
+		name    string
+		snbt    string
+		list    *ListPayload
+		wrapped *ListPayload
+		// NOTE: list when nil
+		unwrapped *ListPayload
+		elemType  TagType
+	}{
+		{
+			name:     `positive case: mixed`,
+			snbt:     `[1b, "a", {x: 1}]`,
+			list:     NewListPayload(NewBytePayload(1), NewStringPayload(`a`), NewCompoundPayload(NewIntTag(NewTagName(`x`), NewIntPayload(1)), NewEndTag())),
+			wrapped:  NewListPayload(NewCompoundPayload(NewByteTag(NewTagName(``), NewBytePayload(1)), NewEndTag()), NewCompoundPayload(NewStringTag(NewTagName(``), NewStringPayload(`a`)), NewEndTag()), NewCompoundPayload(NewIntTag(NewTagName(`x`), NewIntPayload(1)), NewEndTag())),
+			elemType: TagTypeCompound,
+		},
+		{
+			name:      `positive case: compounds with a wrapper`,
+			snbt:      `[{"": 1}, {x: 1}]`,
+			list:      NewListPayload(NewCompoundPayload(NewIntTag(NewTagName(``), NewIntPayload(1)), NewEndTag()), NewCompoundPayload(NewIntTag(NewTagName(`x`), NewIntPayload(1)), NewEndTag())),
+			wrapped:   NewListPayload(NewCompoundPayload(NewIntTag(NewTagName(``), NewIntPayload(1)), NewEndTag()), NewCompoundPayload(NewIntTag(NewTagName(`x`), NewIntPayload(1)), NewEndTag())),
+			unwrapped: NewListPayload(NewIntPayload(1), NewCompoundPayload(NewIntTag(NewTagName(`x`), NewIntPayload(1)), NewEndTag())),
+			elemType:  TagTypeCompound,
+		},
+		{
+			name:     `positive case: homogeneous`,
+			snbt:     `[1, 2]`,
+			list:     NewListPayload(NewIntPayload(1), NewIntPayload(2)),
+			wrapped:  NewListPayload(NewIntPayload(1), NewIntPayload(2)),
+			elemType: TagTypeInt,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := Parse(`{l: ` + tt.snbt + `}`)
+			assert.NoError(t, err)
+			assert.Equal(t, NewCompoundTag(NewTagName(``), NewCompoundPayload(NewListTag(NewTagName(`l`), tt.list), NewEndTag())), parsed)
+			assert.Equal(t, tt.elemType, tt.list.ElemType())
+
+			tag := NewListTag(NewTagName(`l`), tt.list)
+
+			buf := new(bytes.Buffer)
+			assert.NoError(t, Encode(buf, tag))
+			assert.Equal(t, buf.Len(), tag.EncodedSize(new(EncodeOptions)))
+
+			appended, err := tag.AppendNBT(nil, new(EncodeOptions))
+			assert.NoError(t, err)
+			assert.Equal(t, buf.Bytes(), appended)
+
+			decoded, err := Decode(bytes.NewReader(buf.Bytes()))
+			assert.NoError(t, err)
+			assert.Equal(t, NewListTag(NewTagName(`l`), tt.wrapped), decoded)
+
+			expected := tt.unwrapped
+			if expected == nil {
+				expected = tt.list
+			}
+
+			unwrapped, err := DecodeWithOptions(bytes.NewReader(buf.Bytes()), &DecodeOptions{UnwrapLists: true})
+			assert.NoError(t, err)
+			assert.Equal(t, NewListTag(NewTagName(`l`), expected), unwrapped)
+		})
+	}
+}
+
+func TestListPayload_wrappedRoundTrip(t *testing.T) {
+	// NOTE: {l: [1, 2]} as 1.21.5 writes it when the list was heterogeneous, [{"": 1}, {"": 2}]
+	wrapper := func(v byte) []byte {
+		return []byte{0x03, 0x00, 0x00, 0x00, 0x00, 0x00, v, 0x00}
+	}
+
+	raw := []byte{0x0A, 0x00, 0x00, 0x09, 0x00, 0x01, 'l', 0x0A, 0x00, 0x00, 0x00, 0x02}
+	raw = append(raw, wrapper(1)...)
+	raw = append(raw, wrapper(2)...)
+	raw = append(raw, 0x00)
+
+	cases := []struct {
+		name     string
+		opts     *DecodeOptions
+		expected *ListPayload
+	}{
+		{
+			name: `positive case: default`,
+			opts: &DecodeOptions{},
+			expected: NewListPayload(
+				NewCompoundPayload(NewIntTag(NewTagName(``), NewIntPayload(1)), NewEndTag()),
+				NewCompoundPayload(NewIntTag(NewTagName(``), NewIntPayload(2)), NewEndTag()),
+			),
+		},
+		{
+			name:     `positive case: UnwrapLists`,
+			opts:     &DecodeOptions{UnwrapLists: true},
+			expected: &ListPayload{elemType: TagTypeInt, values: []Payload{NewIntPayload(1), NewIntPayload(2)}, unwrapped: true},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			tag, err := DecodeWithOptions(bytes.NewReader(raw), tt.opts)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, (*tag.Payload().(*CompoundPayload))[0].Payload())
+
+			for i := 0; i < 2; i++ {
+				buf := new(bytes.Buffer)
+				assert.NoError(t, Encode(buf, tag))
+				assert.Equal(t, raw, buf.Bytes())
+				assert.Equal(t, len(raw), EncodedSize(tag))
+
+				tag, err = DecodeWithOptions(bytes.NewReader(buf.Bytes()), tt.opts)
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestListPayload_elemType(t *testing.T) {
+	encodedType := func(p *ListPayload) TagType {
+		raw, err := p.AppendNBT(nil, new(EncodeOptions))
+		assert.NoError(t, err)
+		return TagType(raw[0])
+	}
+
+	t.Run(`positive case: declared type is visible`, func(t *testing.T) {
+		assert.NotEqual(t, NewListPayload(), NewEmptyListPayload(TagTypeCompound))
+		assert.Equal(t, NewListPayload(), NewEmptyListPayload(TagTypeEnd))
+	})
+
+	t.Run(`positive case: emptied list keeps its type`, func(t *testing.T) {
+		p := NewEmptyListPayload(TagTypeCompound)
+		p.Append(NewCompoundPayload(NewEndTag()))
+		p.SetValues(p.Values()[:0]...)
+		assert.Equal(t, TagTypeCompound, encodedType(p))
+
+		p = NewListPayload(NewIntPayload(1), NewIntPayload(2))
+		p.SetValues()
+		assert.Equal(t, TagTypeInt, encodedType(p))
+	})
+
+	t.Run(`positive case: resliced values carry no type`, func(t *testing.T) {
+		values := NewListPayload(NewIntPayload(1), NewStringPayload("a")).Values()
+
+		p := NewListPayload(values[:0]...)
+		assert.Equal(t, TagTypeEnd, encodedType(p))
+		assert.Equal(t, NewListPayload(), p)
+
+		p = NewListPayload(values[1:]...)
+		assert.Equal(t, TagTypeString, encodedType(p))
+	})
+
+	t.Run(`positive case: decoded empty list`, func(t *testing.T) {
+		raw := []byte{byte(TagTypeLong), 0x00, 0x00, 0x00, 0x00}
+
+		p := new(ListPayload)
+		err := p.DecodeNBT(bytes.NewReader(raw), new(DecodeOptions))
+		assert.NoError(t, err)
+		assert.Equal(t, NewEmptyListPayload(TagTypeLong), p)
+		assert.Equal(t, TagTypeLong, encodedType(p))
+	})
+}
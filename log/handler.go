@@ -0,0 +1,97 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package log
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+)
+
+var DiscardHandler slog.Handler = discardHandler{}
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// NOTE: records at Info level and above are printed as Println(msg, "func", name, key, value, ...)
+func NewHandler(l Logger) slog.Handler {
+	return &handler{logger: l}
+}
+
+type handler struct {
+	logger Logger
+	attrs  []slog.Attr
+	group  string
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= slog.LevelInfo
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	v := make([]any, 0, 3+2*(len(h.attrs)+r.NumAttrs()))
+	v = append(v, r.Message)
+
+	if r.PC != 0 {
+		fs := runtime.CallersFrames([]uintptr{r.PC})
+		f, _ := fs.Next()
+		v = append(v, "func", f.Function)
+	}
+
+	for _, a := range h.attrs {
+		v = append(v, a.Key, a.Value.Any())
+	}
+
+	r.Attrs(func(a slog.Attr) bool {
+		v = append(v, h.group+a.Key, a.Value.Any())
+		return true
+	})
+
+	h.logger.Println(v...)
+
+	return nil
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	nh := *h
+	nh.attrs = make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	nh.attrs = append(nh.attrs, h.attrs...)
+	for _, a := range attrs {
+		nh.attrs = append(nh.attrs, slog.Attr{Key: h.group + a.Key, Value: a.Value})
+	}
+
+	return &nh
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	nh := *h
+	nh.group = h.group + name + "."
+
+	return &nh
+}
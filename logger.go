@@ -21,35 +21,71 @@
 package nbt
 
 import (
-	"io"
+	"context"
 	"log"
+	"log/slog"
 	"runtime"
-	"sync"
+	"sync/atomic"
+	"time"
 
 	liblog "github.com/Aton-Kish/gonbt/log"
 )
 
-var (
-	logger liblog.Logger = log.New(io.Discard, "", log.LstdFlags)
-	logmu  sync.Mutex
-)
+var defaultLogger atomic.Pointer[slog.Logger]
 
-func SetLogger(l liblog.Logger) {
-	logmu.Lock()
-	defer logmu.Unlock()
+func init() {
+	defaultLogger.Store(slog.New(liblog.DiscardHandler))
+}
 
+func SetLogger(l liblog.Logger) {
 	if l == nil {
 		l = log.Default()
 	}
 
-	logger = l
+	SetSlogLogger(slog.New(liblog.NewHandler(l)))
+}
+
+// NOTE: the default for calls without their own logger
+func SetSlogLogger(l *slog.Logger) {
+	if l == nil {
+		l = slog.Default()
+	}
+
+	defaultLogger.Store(l)
+}
+
+func logEnabled(l *slog.Logger, level slog.Level) bool {
+	if l == nil {
+		l = defaultLogger.Load()
+	}
+
+	return l.Enabled(context.Background(), level)
 }
 
-func getFuncName() string {
-	pc, _, _, ok := runtime.Caller(1)
-	if !ok {
-		return "unknown"
+func logError(l *slog.Logger, msg string, args ...any) {
+	logAt(l, slog.LevelError, msg, args...)
+}
+
+// NOTE: guard calls with logEnabled(l, slog.LevelDebug) so that arguments aren't built when debug logging is disabled
+func logDebug(l *slog.Logger, msg string, args ...any) {
+	logAt(l, slog.LevelDebug, msg, args...)
+}
+
+func logAt(l *slog.Logger, level slog.Level, msg string, args ...any) {
+	if l == nil {
+		l = defaultLogger.Load()
 	}
 
-	return runtime.FuncForPC(pc).Name()
+	ctx := context.Background()
+	if !l.Enabled(ctx, level) {
+		return
+	}
+
+	// NOTE: skip runtime.Callers, logAt and logError or logDebug to record their caller
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:])
+
+	r := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	r.Add(args...)
+	_ = l.Handler().Handle(ctx, r)
 }
@@ -0,0 +1,180 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	liblog "github.com/Aton-Kish/gonbt/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func restoreLogger(t *testing.T) {
+	t.Cleanup(func() {
+		SetSlogLogger(slog.New(liblog.DiscardHandler))
+	})
+}
+
+func TestSetSlogLogger_debug(t *testing.T) {
+	restoreLogger(t)
+
+	buf := new(bytes.Buffer)
+	SetSlogLogger(slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	_, err := Decode(bytes.NewBuffer(nbtCases[0].raw))
+	assert.NoError(t, err)
+
+	records := []map[string]any{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+
+	assert.NotEmpty(t, records)
+	assert.Equal(t, "DEBUG", records[0]["level"])
+	assert.Equal(t, "decoding tag", records[0]["msg"])
+	assert.Equal(t, "Compound", records[0]["type"])
+	assert.Equal(t, "", records[0]["name"])
+	assert.Equal(t, "Hello World", records[1]["name"])
+}
+
+func TestSetSlogLogger_error(t *testing.T) {
+	restoreLogger(t)
+
+	buf := new(bytes.Buffer)
+	SetSlogLogger(slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{AddSource: true})))
+
+	_, err := Decode(bytes.NewBuffer([]byte{0x0D}))
+	assert.Error(t, err)
+
+	assert.Contains(t, buf.String(), "level=ERROR")
+	assert.Contains(t, buf.String(), `msg="failed to decode"`)
+	assert.Contains(t, buf.String(), "tag.go:")
+	assert.NotContains(t, buf.String(), "level=DEBUG")
+	assert.NotContains(t, buf.String(), "logger.go:")
+}
+
+func TestSetLogger(t *testing.T) {
+	restoreLogger(t)
+
+	buf := new(bytes.Buffer)
+	SetLogger(log.New(buf, "", 0))
+
+	_, err := Decode(bytes.NewBuffer([]byte{0x0D}))
+	assert.Error(t, err)
+
+	line := strings.SplitN(buf.String(), "\n", 2)[0]
+	assert.True(t, strings.HasPrefix(line, "failed to new func github.com/Aton-Kish/gonbt.NewTag error nbt new: invalid tag type"), line)
+}
+
+func TestLogDisabled(t *testing.T) {
+	raw := nbtCases[1].raw
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, err := Decode(bytes.NewBuffer(raw)); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	restoreLogger(t)
+	SetSlogLogger(slog.New(slog.NewTextHandler(new(bytes.Buffer), nil)))
+
+	expected := testing.AllocsPerRun(100, func() {
+		if _, err := Decode(bytes.NewBuffer(raw)); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	assert.Equal(t, expected, allocs)
+}
+
+func TestDecodeOptions_Logger(t *testing.T) {
+	restoreLogger(t)
+
+	global := new(bytes.Buffer)
+	SetSlogLogger(slog.New(slog.NewTextHandler(global, nil)))
+
+	bufs := []*bytes.Buffer{new(bytes.Buffer), new(bytes.Buffer)}
+
+	var wg sync.WaitGroup
+	for _, buf := range bufs {
+		buf := buf
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			opts := &DecodeOptions{Logger: slog.New(slog.NewTextHandler(buf, nil))}
+			_, err := DecodeWithOptions(bytes.NewBuffer([]byte{0x08, 0x00, 0x01, 'a', 0x00}), opts)
+			assert.Error(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Empty(t, global.String())
+	for _, buf := range bufs {
+		assert.Contains(t, buf.String(), `msg="failed to decode"`)
+		assert.Equal(t, strings.Count(bufs[0].String(), "\n"), strings.Count(buf.String(), "\n"))
+	}
+}
+
+func TestParseOptions_Logger(t *testing.T) {
+	restoreLogger(t)
+
+	global := new(bytes.Buffer)
+	SetSlogLogger(slog.New(slog.NewTextHandler(global, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	buf := new(bytes.Buffer)
+	opts := &ParseOptions{Logger: slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))}
+	_, err := ParseWithOptions(`{a: 1b}`, opts)
+	assert.NoError(t, err)
+
+	assert.Empty(t, global.String())
+	assert.Contains(t, buf.String(), `msg="parsing tag" type=Compound`)
+	assert.Contains(t, buf.String(), `msg="next token"`)
+}
+
+func TestSetSlogLogger_concurrent(t *testing.T) {
+	restoreLogger(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetSlogLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = Decode(bytes.NewBuffer([]byte{0x0D}))
+		}()
+	}
+
+	wg.Wait()
+}
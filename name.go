@@ -21,12 +21,9 @@
 package nbt
 
 import (
-	"encoding/binary"
-	"fmt"
 	"io"
 	"regexp"
 	"strconv"
-	"strings"
 
 	"github.com/Aton-Kish/gonbt/pointer"
 	"github.com/Aton-Kish/gonbt/snbt"
@@ -44,18 +41,9 @@ func (n TagName) String() string {
 	return n.stringify()
 }
 
-func (n *TagName) encode(w io.Writer) error {
-	l := uint16(len(*n))
-	if err := binary.Write(w, binary.BigEndian, &l); err != nil {
-		err = &NbtError{Op: "encode", Err: err}
-		logger.Println("failed to encode", "func", getFuncName(), "name", n, "error", err)
-		return err
-	}
-
-	b := []byte(*n)
-	if err := binary.Write(w, binary.BigEndian, b); err != nil {
-		err = &NbtError{Op: "encode", Err: err}
-		logger.Println("failed to encode", "func", getFuncName(), "name", n, "error", err)
+func (n *TagName) encode(w io.Writer, opts *EncodeOptions) error {
+	if err := writeString(w, string(*n)); err != nil {
+		logError(opts.logger(), "failed to encode", "name", n, "error", err)
 		return err
 	}
 
@@ -63,20 +51,15 @@ func (n *TagName) encode(w io.Writer) error {
 }
 
 func (n *TagName) decode(r io.Reader) error {
-	var l uint16
-	if err := binary.Read(r, binary.BigEndian, &l); err != nil {
-		err = &NbtError{Op: "decode", Err: err}
-		logger.Println("failed to decode", "func", getFuncName(), "name", n, "error", err)
-		return err
-	}
+	br := asByteReader(r, nil)
 
-	b := make([]byte, l)
-	if err := binary.Read(r, binary.BigEndian, b); err != nil {
-		err = &NbtError{Op: "decode", Err: err}
-		logger.Println("failed to decode", "func", getFuncName(), "name", n, "error", err)
+	s, err := readString(br)
+	if err != nil {
+		logError(br.logger, "failed to decode", "name", n, "error", err)
 		return err
 	}
-	*n = TagName(b)
+
+	*n = TagName(s)
 
 	return nil
 }
@@ -87,33 +70,65 @@ func (n *TagName) stringify() string {
 		return s
 	}
 
-	qs := strconv.Quote(s)
-	if strings.Contains(s, "\"") && !strings.Contains(s, "'") {
-		qs = fmt.Sprintf("'%s'", qs[1:len(qs)-1])
-		qs = strings.ReplaceAll(qs, "\\\"", "\"")
-		return qs
+	return string(appendQuote(nil, s))
+}
+
+func (n *TagName) appendSNBT(dst []byte, opts *StringifyOptions) []byte {
+	if opts.Dialect == DialectDefault {
+		if !quoteRequiredCharacters.MatchString(string(*n)) {
+			return append(dst, *n...)
+		}
+
+		return opts.appendQuote(dst, string(*n))
+	}
+
+	if unquotedKeyPattern.MatchString(string(*n)) {
+		return append(dst, *n...)
 	}
 
-	return qs
+	return opts.appendQuote(dst, string(*n))
 }
 
 func (n *TagName) parse(parser *snbt.Parser) error {
-	b, err := parser.Slice(parser.PrevToken().Index()+1, parser.CurrToken().Index())
+	start, end := parser.PrevToken().Index()+1, parser.CurrToken().Index()
+	b, err := parser.Slice(start, end)
 	if err != nil {
 		err = &NbtError{Op: "parse", Err: err}
-		logger.Println("failed to parse", "func", getFuncName(), "name", n, "error", err)
+		logError(parser.Logger(), "failed to parse", "name", n, "error", err)
 		return err
 	}
 
-	if s, err := strconv.Unquote(string(b)); err != nil {
-		*n = TagName(b)
-	} else {
+	if parser.CurrToken().Char() != ':' {
+		var err error
+		if len(b) == 0 {
+			err = parser.ErrorAt(end, "parse", expected("key, found %q", parser.CurrToken().Char()))
+		} else {
+			// NOTE: the key ends where Compact removed whitespace, which is where ':' was expected
+			gap := parser.Gap(start, end)
+			err = parser.ErrorAt(gap, "parse", expected("':' after key %q", b[:gap-start]))
+		}
+
+		err = &NbtError{Op: "parse", Err: err}
+		logError(parser.Logger(), "failed to parse", "name", n, "error", err)
+		return err
+	}
+
+	if len(b) > 0 && (b[0] == '"' || b[0] == '\'') {
+		s, err := unquote(string(b))
+		if err != nil {
+			err = &NbtError{Op: "parse", Err: expected("key, found %q", b)}
+			logError(parser.Logger(), "failed to parse", "name", n, "error", err)
+			return err
+		}
+
 		*n = TagName(s)
+	} else {
+		*n = TagName(b)
 	}
 
 	if err := parser.Next(); err != nil {
 		err = &NbtError{Op: "parse", Err: err}
-		logger.Println("failed to parse", "func", getFuncName(), "name", n, "error", err)
+		logError(parser.Logger(), "failed to parse", "name", n, "error", err)
 		return err
 	}
 
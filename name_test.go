@@ -75,7 +75,7 @@ func TestTagName_encode(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			buf := new(bytes.Buffer)
-			err := tt.tagName.encode(buf)
+			err := tt.tagName.encode(buf, new(EncodeOptions))
 
 			if tt.expectedErr == nil {
 				assert.NoError(t, err)
@@ -0,0 +1,62 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"math"
+	"strconv"
+)
+
+// NOTE: how Json writes NaN and infinities, which JSON can't express
+type NonFiniteStyle byte
+
+const (
+	// NOTE: null
+	NonFiniteNull NonFiniteStyle = iota
+	// NOTE: "NaN", "Infinity" and "-Infinity"
+	NonFiniteString
+	// NOTE: null, and JsonWithOptions reports ErrNonFinite
+	NonFiniteError
+)
+
+func (o *JsonOptions) appendFloat(dst []byte, f float64, bitSize int) []byte {
+	if !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return strconv.AppendFloat(dst, f, 'g', -1, bitSize)
+	}
+
+	switch o.NonFinite {
+	case NonFiniteString:
+		switch {
+		case math.IsNaN(f):
+			return append(dst, `"NaN"`...)
+		case f > 0:
+			return append(dst, `"Infinity"`...)
+		default:
+			return append(dst, `"-Infinity"`...)
+		}
+	case NonFiniteError:
+		if o.err == nil {
+			o.err = &NbtError{Op: "json", Err: ErrNonFinite}
+		}
+	}
+
+	return append(dst, "null"...)
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var floatEdgeCases = []struct {
+	name   string
+	value  float64
+	float  string
+	double string
+}{
+	{name: `zero`, value: 0, float: `0f`, double: `0d`},
+	{name: `negative zero`, value: math.Copysign(0, -1), float: `-0f`, double: `-0d`},
+	{name: `smallest subnormal`, value: math.SmallestNonzeroFloat32, float: `1e-45f`, double: `1.401298464324817e-45d`},
+	{name: `largest subnormal`, value: float64(math.Float32frombits(0x007FFFFF)), float: `1.1754942e-38f`, double: `1.1754942106924411e-38d`},
+	{name: `smallest normal`, value: float64(math.Float32frombits(0x00800000)), float: `1.1754944e-38f`, double: `1.1754943508222875e-38d`},
+	{name: `max`, value: math.MaxFloat32, float: `3.4028235e+38f`, double: `3.4028234663852886e+38d`},
+	{name: `NaN`, value: math.NaN(), float: `NaNf`, double: `NaNd`},
+	{name: `positive infinity`, value: math.Inf(1), float: `Infinityf`, double: `Infinityd`},
+	{name: `negative infinity`, value: math.Inf(-1), float: `-Infinityf`, double: `-Infinityd`},
+}
+
+func assertSameFloat(t *testing.T, expected float64, actual float64) {
+	if math.IsNaN(expected) {
+		assert.True(t, math.IsNaN(actual))
+		return
+	}
+
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, math.Signbit(expected), math.Signbit(actual))
+}
+
+func TestFloatPayload_nonFinite(t *testing.T) {
+	for _, tt := range floatEdgeCases {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewFloatPayload(float32(tt.value))
+
+			snbt := string(p.AppendSNBT(nil, &StringifyOptions{}, 0))
+			assert.Equal(t, tt.float, snbt)
+
+			tag, err := Parse(`{v: ` + snbt + `}`)
+			assert.NoError(t, err)
+
+			actual := (*tag.Payload().(*CompoundPayload))[0].Payload().(*FloatPayload)
+			assertSameFloat(t, float64(float32(tt.value)), float64(*actual))
+		})
+	}
+}
+
+func TestDoublePayload_nonFinite(t *testing.T) {
+	cases := append(floatEdgeCases[:len(floatEdgeCases):len(floatEdgeCases)], []struct {
+		name   string
+		value  float64
+		float  string
+		double string
+	}{
+		{name: `smallest double subnormal`, value: math.SmallestNonzeroFloat64, double: `5e-324d`},
+		{name: `largest double subnormal`, value: math.Float64frombits(0x000FFFFFFFFFFFFF), double: `2.225073858507201e-308d`},
+		{name: `max double`, value: math.MaxFloat64, double: `1.7976931348623157e+308d`},
+	}...)
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			value := float64(float32(tt.value))
+			if tt.float == "" {
+				value = tt.value
+			}
+
+			p := NewDoublePayload(value)
+
+			snbt := string(p.AppendSNBT(nil, &StringifyOptions{}, 0))
+			assert.Equal(t, tt.double, snbt)
+
+			tag, err := Parse(`{v: ` + snbt + `}`)
+			assert.NoError(t, err)
+
+			actual := (*tag.Payload().(*CompoundPayload))[0].Payload().(*DoublePayload)
+			assertSameFloat(t, value, float64(*actual))
+		})
+	}
+}
+
+func TestParse_nonFiniteError(t *testing.T) {
+	cases := []struct {
+		name string
+		snbt string
+	}{
+		{name: `negative case: no suffix`, snbt: `{v: NaN}`},
+		{name: `negative case: signed NaN`, snbt: `{v: -NaNd}`},
+		{name: `negative case: short infinity`, snbt: `{v: Inff}`},
+		{name: `negative case: integer suffix`, snbt: `{v: Infinityb}`},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.snbt)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestJsonWithOptions_nonFinite(t *testing.T) {
+	tag := NewCompoundTag(NewTagName(``), NewCompoundPayload(
+		NewDoubleTag(NewTagName(`a`), NewDoublePayload(math.NaN())),
+		NewFloatTag(NewTagName(`b`), NewFloatPayload(float32(math.Inf(1)))),
+		NewListTag(NewTagName(`c`), NewListPayload(NewDoublePayload(math.Inf(-1)), NewDoublePayload(math.Copysign(0, -1)))),
+		NewEndTag(),
+	))
+
+	cases := []struct {
+		name     string
+		style    NonFiniteStyle
+		expected string
+		err      error
+	}{
+		{
+			name:     `positive case: null`,
+			style:    NonFiniteNull,
+			expected: `{"a":null,"b":null,"c":[null,-0]}`,
+		},
+		{
+			name:     `positive case: string`,
+			style:    NonFiniteString,
+			expected: `{"a":"NaN","b":"Infinity","c":["-Infinity",-0]}`,
+		},
+		{
+			name:  `negative case: error`,
+			style: NonFiniteError,
+			err:   ErrNonFinite,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := JsonWithOptions(tag, &JsonOptions{NonFinite: tt.style})
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestJsonWithOptions_finite(t *testing.T) {
+	tag := NewCompoundTag(NewTagName(``), NewCompoundPayload(
+		NewDoubleTag(NewTagName(`a`), NewDoublePayload(1.5)),
+		NewEndTag(),
+	))
+
+	actual, err := JsonWithOptions(tag, &JsonOptions{NonFinite: NonFiniteError})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":1.5}`, actual)
+}
@@ -0,0 +1,297 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// NOTE: an SNBT number split into its parts, underscores removed
+type number struct {
+	typ        TagType
+	negative   bool
+	base       int
+	signedness byte
+	// NOTE: the integer digits without sign and prefix, or the whole float without suffix
+	digits string
+	text   string
+}
+
+// NOTE: recognizes the numeric grammar of 1.21.5, e.g. 0xFFub, -0b1010s, 1_000_000L, .5f, 1., 1e5
+func scanNumber(b []byte) (*number, bool) {
+	s := string(b)
+	n := &number{text: s, base: 10}
+
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		n.negative = s[i] == '-'
+		i++
+	}
+
+	// NOTE: not vanilla SNBT, written by Stringify for non-finite values and always suffixed
+	for _, word := range []string{"NaN", "Infinity"} {
+		if !strings.HasPrefix(s[i:], word) || word == "NaN" && i > 0 {
+			continue
+		}
+
+		switch strings.ToLower(s[i+len(word):]) {
+		case "f":
+			n.typ = TagTypeFloat
+		case "d":
+			n.typ = TagTypeDouble
+		default:
+			return nil, false
+		}
+
+		n.digits = s[:i] + word
+
+		return n, true
+	}
+
+	if i+2 < len(s) && s[i] == '0' {
+		switch {
+		case (s[i+1] == 'x' || s[i+1] == 'X') && isDigit(s[i+2], 16):
+			n.base = 16
+		case (s[i+1] == 'b' || s[i+1] == 'B') && isDigit(s[i+2], 2):
+			n.base = 2
+		}
+
+		if n.base != 10 {
+			i += 2
+		}
+	}
+
+	start := i
+	intPart, i, ok := scanDigits(s, i, n.base)
+	if !ok {
+		return nil, false
+	}
+
+	if n.base == 10 {
+		var frac, exp string
+		hasPoint, hasExp := false, false
+
+		if i < len(s) && s[i] == '.' {
+			hasPoint = true
+			if frac, i, ok = scanDigits(s, i+1, 10); !ok {
+				return nil, false
+			}
+		}
+
+		if i < len(s) && (s[i] == 'e' || s[i] == 'E') && (intPart != "" || frac != "") {
+			hasExp = true
+			j := i + 1
+			sign := ""
+			if j < len(s) && (s[j] == '+' || s[j] == '-') {
+				sign = s[j : j+1]
+				j++
+			}
+
+			if exp, i, ok = scanDigits(s, j, 10); !ok || exp == "" {
+				return nil, false
+			}
+
+			exp = sign + exp
+		}
+
+		if intPart == "" && frac == "" {
+			return nil, false
+		}
+
+		suffix := strings.ToLower(s[i:])
+		isFloat := hasPoint || hasExp || suffix == "f" || suffix == "d"
+		if isFloat {
+			switch suffix {
+			case "f":
+				n.typ = TagTypeFloat
+			case "d", "":
+				n.typ = TagTypeDouble
+			default:
+				return nil, false
+			}
+
+			n.digits = s[:start] + intPart
+			if hasPoint {
+				n.digits += "." + frac
+			}
+
+			if hasExp {
+				n.digits += "e" + exp
+			}
+
+			return n, true
+		}
+	}
+
+	if intPart == "" {
+		return nil, false
+	}
+
+	n.digits = intPart
+
+	suffix := strings.ToLower(s[i:])
+	if len(suffix) == 2 && (suffix[0] == 's' || suffix[0] == 'u') {
+		n.signedness = suffix[0]
+		suffix = suffix[1:]
+	}
+
+	switch suffix {
+	case "b":
+		n.typ = TagTypeByte
+	case "s":
+		n.typ = TagTypeShort
+	case "", "i":
+		n.typ = TagTypeInt
+	case "l":
+		n.typ = TagTypeLong
+	default:
+		return nil, false
+	}
+
+	return n, true
+}
+
+// NOTE: digits may be separated by underscores but neither start nor end with one
+func scanDigits(s string, i int, base int) (string, int, bool) {
+	start := i
+	for i < len(s) && (isDigit(s[i], base) || s[i] == '_') {
+		i++
+	}
+
+	digits := s[start:i]
+	if digits == "" {
+		return "", i, true
+	}
+
+	if digits[0] == '_' || digits[len(digits)-1] == '_' {
+		return "", i, false
+	}
+
+	return strings.ReplaceAll(digits, "_", ""), i, true
+}
+
+func isDigit(c byte, base int) bool {
+	switch base {
+	case 2:
+		return c == '0' || c == '1'
+	case 16:
+		return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+	default:
+		return '0' <= c && c <= '9'
+	}
+}
+
+func bitSize(typ TagType) int {
+	switch typ {
+	case TagTypeByte:
+		return 8
+	case TagTypeShort:
+		return 16
+	case TagTypeInt, TagTypeFloat:
+		return 32
+	default:
+		return 64
+	}
+}
+
+// NOTE: unsigned values and hexadecimal or binary values without signedness may use the whole bit width, e.g. 255ub and 0xFFFFFFFF are -1
+func (n *number) integer() (int64, error) {
+	bits := bitSize(n.typ)
+
+	mag, err := strconv.ParseUint(n.digits, n.base, 64)
+	if err != nil {
+		return 0, n.outOfRange()
+	}
+
+	unsigned := n.signedness == 'u' || n.signedness == 0 && n.base != 10 && !n.negative
+	if unsigned {
+		if n.negative || bits < 64 && mag >= 1<<bits {
+			return 0, n.outOfRange()
+		}
+
+		// NOTE: reinterpret the bits as a signed value of the same width
+		return int64(mag<<(64-bits)) >> (64 - bits), nil
+	}
+
+	if n.negative {
+		if mag > 1<<(bits-1) {
+			return 0, n.outOfRange()
+		}
+
+		return -int64(mag), nil
+	}
+
+	if mag >= 1<<(bits-1) {
+		return 0, n.outOfRange()
+	}
+
+	return int64(mag), nil
+}
+
+func (n *number) float() (float64, error) {
+	f, err := strconv.ParseFloat(n.digits, bitSize(n.typ))
+	if err != nil {
+		return 0, n.outOfRange()
+	}
+
+	return f, nil
+}
+
+func (n *number) outOfRange() error {
+	return fmt.Errorf("%s: %w", n.text, ErrOutOfRange)
+}
+
+func parseInteger(b []byte, typ TagType) (int64, error) {
+	n, ok := scanNumber(b)
+	if !ok || n.typ != typ {
+		return 0, expected("%s, found %q", typ, b)
+	}
+
+	return n.integer()
+}
+
+func parseFloat(b []byte, typ TagType) (float64, error) {
+	n, ok := scanNumber(b)
+	if !ok || n.typ != typ {
+		return 0, expected("%s, found %q", typ, b)
+	}
+
+	return n.float()
+}
+
+// NOTE: NaN and infinities are written as NaN, Infinity and -Infinity, which Parse reads back but vanilla does not, the NaN payload bits are not kept
+func appendSnbtFloat(dst []byte, f float64, bitSize int, suffix byte) []byte {
+	switch {
+	case math.IsNaN(f):
+		dst = append(dst, "NaN"...)
+	case math.IsInf(f, 1):
+		dst = append(dst, "Infinity"...)
+	case math.IsInf(f, -1):
+		dst = append(dst, "-Infinity"...)
+	default:
+		dst = strconv.AppendFloat(dst, f, 'g', -1, bitSize)
+	}
+
+	return append(dst, suffix)
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_number(t *testing.T) {
+	cases := []struct {
+		name        string
+		snbt        string
+		expected    Payload
+		expectedErr error
+	}{
+		{name: `positive case: Byte`, snbt: `-12b`, expected: NewBytePayload(-12)},
+		{name: `positive case: Byte - zero`, snbt: `0b`, expected: NewBytePayload(0)},
+		{name: `positive case: Byte - unsigned`, snbt: `255ub`, expected: NewBytePayload(-1)},
+		{name: `positive case: Byte - signed`, snbt: `-128sb`, expected: NewBytePayload(-128)},
+		{name: `positive case: Byte - binary`, snbt: `0b1010b`, expected: NewBytePayload(10)},
+		{name: `positive case: Byte - hexadecimal`, snbt: `0x7Fsb`, expected: NewBytePayload(127)},
+		{name: `positive case: Short`, snbt: `1_000s`, expected: NewShortPayload(1000)},
+		{name: `positive case: Short - unsigned`, snbt: `65535US`, expected: NewShortPayload(-1)},
+		{name: `positive case: Int`, snbt: `+42`, expected: NewIntPayload(42)},
+		{name: `positive case: Int - suffix`, snbt: `42i`, expected: NewIntPayload(42)},
+		{name: `positive case: Int - hexadecimal`, snbt: `0xFFFFFFFF`, expected: NewIntPayload(-1)},
+		{name: `positive case: Int - hexadecimal ending with b`, snbt: `0xFFb`, expected: NewIntPayload(0xFFB)},
+		{name: `positive case: Int - negative hexadecimal`, snbt: `-0x10`, expected: NewIntPayload(-16)},
+		{name: `positive case: Int - binary`, snbt: `0b1111_0000`, expected: NewIntPayload(240)},
+		{name: `positive case: Long`, snbt: `-9223372036854775808L`, expected: NewLongPayload(math.MinInt64)},
+		{name: `positive case: Long - unsigned`, snbt: `0xFFFFFFFFFFFFFFFFul`, expected: NewLongPayload(-1)},
+		{name: `positive case: Float`, snbt: `.5f`, expected: NewFloatPayload(0.5)},
+		{name: `positive case: Float - integer`, snbt: `3F`, expected: NewFloatPayload(3)},
+		{name: `positive case: Float - exponent`, snbt: `1.5e-3f`, expected: NewFloatPayload(1.5e-3)},
+		{name: `positive case: Double - trailing point`, snbt: `1.`, expected: NewDoublePayload(1)},
+		{name: `positive case: Double - exponent`, snbt: `1e5`, expected: NewDoublePayload(1e5)},
+		{name: `positive case: Double - underscores`, snbt: `1_000.000_5d`, expected: NewDoublePayload(1000.0005)},
+		{name: `negative case: Byte overflow`, snbt: `128b`, expectedErr: ErrOutOfRange},
+		{name: `negative case: Byte underflow`, snbt: `-129b`, expectedErr: ErrOutOfRange},
+		{name: `negative case: unsigned Byte overflow`, snbt: `256ub`, expectedErr: ErrOutOfRange},
+		{name: `negative case: negative unsigned`, snbt: `-1ub`, expectedErr: ErrOutOfRange},
+		{name: `negative case: Int overflow`, snbt: `2147483648`, expectedErr: ErrOutOfRange},
+		{name: `negative case: hexadecimal overflow`, snbt: `0x1FFFFFFFF`, expectedErr: ErrOutOfRange},
+		{name: `negative case: Long overflow`, snbt: `99999999999999999999L`, expectedErr: ErrOutOfRange},
+		{name: `negative case: Float overflow`, snbt: `1e39f`, expectedErr: ErrOutOfRange},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := Parse(`{Value: ` + tt.snbt + `}`)
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, NewCompoundTag(NewTagName(``), NewCompoundPayload(
+					newTag(t, NewTagName(`Value`), tt.expected),
+					NewEndTag(),
+				)), actual)
+			} else {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestParse_numberArray(t *testing.T) {
+	cases := []struct {
+		name        string
+		snbt        string
+		expected    Payload
+		expectedErr error
+	}{
+		{name: `positive case: ByteArray`, snbt: `[B; 0x7Fsb, -1b, 255ub]`, expected: NewByteArrayPayload(127, -1, -1)},
+		{name: `positive case: IntArray`, snbt: `[I; 1_000, 0xFFFFFFFF, 2i]`, expected: NewIntArrayPayload(1000, -1, 2)},
+		{name: `positive case: LongArray`, snbt: `[L; 4294967296L, -1L]`, expected: NewLongArrayPayload(4294967296, -1)},
+		{name: `negative case: ByteArray overflow`, snbt: `[B; 128b]`, expectedErr: ErrOutOfRange},
+		{name: `negative case: IntArray mismatched element`, snbt: `[I; 1L]`, expectedErr: ErrInvalidSnbtFormat},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := Parse(`{Value: ` + tt.snbt + `}`)
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, NewCompoundTag(NewTagName(``), NewCompoundPayload(
+					newTag(t, NewTagName(`Value`), tt.expected),
+					NewEndTag(),
+				)), actual)
+			} else {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func newTag(t *testing.T, name *TagName, payload Payload) Tag {
+	tag, err := newTagWithPayload(name, payload)
+	assert.NoError(t, err)
+	return tag
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"encoding/hex"
+	"regexp"
+	"strings"
+
+	"github.com/Aton-Kish/gonbt/snbt"
+)
+
+var (
+	operationPattern = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)\((.*)\)$`)
+	uuidPattern      = regexp.MustCompile(`^[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}$`)
+)
+
+// NOTE: evaluates the 1.21.5 operations bool(x) and uuid("...")
+func evalOperation(b []byte) (Payload, error) {
+	g := operationPattern.FindSubmatch(b)
+	if g == nil {
+		return nil, ErrInvalidSnbtFormat
+	}
+
+	arg := g[2]
+
+	switch string(g[1]) {
+	case "bool":
+		if g := booleanPattern.FindSubmatch(arg); g != nil {
+			return boolPayload(len(g[1]) > 0), nil
+		}
+
+		n, ok := scanNumber(arg)
+		if !ok {
+			return nil, ErrInvalidSnbtFormat
+		}
+
+		switch n.typ {
+		case TagTypeFloat, TagTypeDouble:
+			f, err := n.float()
+			if err != nil {
+				return nil, err
+			}
+
+			return boolPayload(f != 0), nil
+		default:
+			i, err := n.integer()
+			if err != nil {
+				return nil, err
+			}
+
+			return boolPayload(i != 0), nil
+		}
+	case "uuid":
+		s, err := unquote(string(arg))
+		if err != nil || !uuidPattern.MatchString(s) {
+			return nil, expected("UUID string, found %q", arg)
+		}
+
+		v, err := hex.DecodeString(strings.ReplaceAll(s, "-", ""))
+		if err != nil {
+			return nil, err
+		}
+
+		p := make(IntArrayPayload, 4)
+		for i := range p {
+			p[i] = int32(uint32(v[i*4])<<24 | uint32(v[i*4+1])<<16 | uint32(v[i*4+2])<<8 | uint32(v[i*4+3]))
+		}
+
+		return &p, nil
+	default:
+		return nil, ErrInvalidSnbtFormat
+	}
+}
+
+func boolPayload(v bool) *BytePayload {
+	if v {
+		return NewBytePayload(1)
+	}
+
+	return NewBytePayload(0)
+}
+
+// NOTE: reports false when the value under the parser is not an operation, otherwise stores its result in p
+func parseOperation[T any, P interface {
+	*T
+	Payload
+}](parser *snbt.Parser, p P) (bool, error) {
+	b, err := parser.Slice(parser.PrevToken().Index()+1, parser.CurrToken().Index())
+	if err != nil || !operationPattern.Match(b) {
+		return false, nil
+	}
+
+	v, err := evalOperation(b)
+	if err != nil {
+		return true, err
+	}
+
+	r, ok := v.(P)
+	if !ok {
+		return true, ErrInvalidSnbtFormat
+	}
+
+	*p = *r
+
+	return true, nil
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_operation(t *testing.T) {
+	cases := []struct {
+		name     string
+		snbt     string
+		expected Tag
+	}{
+		{
+			name: `positive case: bool`,
+			snbt: `{a: bool(1), b: bool(0b), c: bool(-2.5d), d: bool(true), e: bool(false)}`,
+			expected: NewCompoundTag(NewTagName(``), NewCompoundPayload(
+				NewByteTag(NewTagName(`a`), NewBytePayload(1)),
+				NewByteTag(NewTagName(`b`), NewBytePayload(0)),
+				NewByteTag(NewTagName(`c`), NewBytePayload(1)),
+				NewByteTag(NewTagName(`d`), NewBytePayload(1)),
+				NewByteTag(NewTagName(`e`), NewBytePayload(0)),
+				NewEndTag(),
+			)),
+		},
+		{
+			name: `positive case: uuid`,
+			snbt: `{UUID: uuid("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")}`,
+			expected: NewCompoundTag(NewTagName(``), NewCompoundPayload(
+				NewIntArrayTag(NewTagName(`UUID`), NewIntArrayPayload(-132296786, 2112623056, -1486552928, -920753162)),
+				NewEndTag(),
+			)),
+		},
+		{
+			name: `positive case: list`,
+			snbt: `{Flags: [bool(1), 0b, bool(0)]}`,
+			expected: NewCompoundTag(NewTagName(``), NewCompoundPayload(
+				NewListTag(NewTagName(`Flags`), NewListPayload(NewBytePayload(1), NewBytePayload(0), NewBytePayload(0))),
+				NewEndTag(),
+			)),
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := Parse(tt.snbt)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestParse_operationError(t *testing.T) {
+	cases := []struct {
+		name string
+		snbt string
+	}{
+		{name: `negative case: unknown operation`, snbt: `{a: foo(1)}`},
+		{name: `negative case: bool of string`, snbt: `{a: bool("x")}`},
+		{name: `negative case: malformed uuid`, snbt: `{a: uuid("f81d4fae")}`},
+		{name: `negative case: unquoted uuid`, snbt: `{a: uuid(f81d4fae-7dec-11d0-a765-00a0c91e6bf6)}`},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.snbt)
+			assert.Error(t, err)
+		})
+	}
+}
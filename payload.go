@@ -21,29 +21,21 @@
 package nbt
 
 import (
-	"io"
-	"regexp"
-
 	"github.com/Aton-Kish/gonbt/snbt"
 )
 
-var (
-	bytePattern   = regexp.MustCompile(`^(-?\d+)[Bb]$`)
-	shortPattern  = regexp.MustCompile(`^(-?\d+)[Ss]$`)
-	intPattern    = regexp.MustCompile(`^-?\d+$`)
-	longPattern   = regexp.MustCompile(`^(-?\d+)[Ll]$`)
-	floatPattern  = regexp.MustCompile(`^(-?\d+(\.\d+)?([Ee][+-]?\d+)?)[Ff]$`)
-	doublePattern = regexp.MustCompile(`^(-?\d+(\.\d+)?([Ee][+-]?\d+)?)[Dd]?$`)
-)
-
 type Payload interface {
 	String() string
 	TypeId() TagType
-	encode(w io.Writer) error
-	decode(r io.Reader) error
-	stringify(space string, indent string, depth int) string
+	NbtEncoder
+	NbtDecoder
+	SnbtAppender
+	JsonAppender
+}
+
+type snbtPayload interface {
+	Payload
 	parse(parser *snbt.Parser) error
-	json(space string, indent string, depth int) string
 }
 
 func NewPayload(typ TagType) (Payload, error) {
@@ -74,12 +66,24 @@ func NewPayload(typ TagType) (Payload, error) {
 		return new(LongArrayPayload), nil
 	default:
 		err := &NbtError{Op: "new", Err: ErrInvalidTagType}
-		logger.Println("failed to new", "func", getFuncName(), "error", err)
+		logError(nil, "failed to new", "error", err)
 		return nil, err
 	}
 }
 
-func newPayloadFromSnbt(parser *snbt.Parser) (Payload, error) {
+// NOTE: a value in a compound or list is followed by ',' or the closing token
+func expectSeparator(parser *snbt.Parser, closing rune) error {
+	switch c := parser.CurrToken().Char(); c {
+	case ',', closing:
+		return nil
+	case *new(rune):
+		return &NbtError{Op: "parse", Err: parser.ErrorAt(parser.CurrToken().Index(), "parse", snbt.ErrUnexpectedEnd)}
+	default:
+		return &NbtError{Op: "parse", Err: parser.ErrorAt(parser.CurrToken().Index(), "parse", expected("',' or %q, found %q", closing, c))}
+	}
+}
+
+func newPayloadFromSnbt(parser *snbt.Parser) (snbtPayload, error) {
 	switch parser.CurrToken().Char() {
 	case '{':
 		return new(CompoundPayload), nil
@@ -87,7 +91,7 @@ func newPayloadFromSnbt(parser *snbt.Parser) (Payload, error) {
 		typ, err := parser.Char(parser.CurrToken().Index() + 1)
 		if err != nil {
 			err = &NbtError{Op: "new", Err: err}
-			logger.Println("failed to new", "func", getFuncName(), "error", err)
+			logError(parser.Logger(), "failed to new", "error", err)
 			return nil, err
 		}
 
@@ -101,41 +105,60 @@ func newPayloadFromSnbt(parser *snbt.Parser) (Payload, error) {
 		}
 
 		return new(ListPayload), nil
-	case *new(rune), '"', ' ', ':', ';':
+	case *new(rune), '"', ' ', ';':
 		err := &NbtError{Op: "new", Err: ErrInvalidSnbtFormat}
-		logger.Println("failed to new", "func", getFuncName(), "error", err)
+		logError(parser.Logger(), "failed to new", "error", err)
 		return nil, err
 	}
 
-	b, err := parser.Slice(parser.PrevToken().Index()+1, parser.CurrToken().Index())
+	start, end := parser.PrevToken().Index()+1, parser.CurrToken().Index()
+	b, err := parser.Slice(start, end)
 	if err != nil {
 		err = &NbtError{Op: "new", Err: err}
-		logger.Println("failed to new", "func", getFuncName(), "error", err)
+		logError(parser.Logger(), "failed to new", "error", err)
 		return nil, err
 	}
 
-	if bytePattern.Match(b) {
-		return new(BytePayload), nil
+	if len(b) == 0 {
+		err := &NbtError{Op: "new", Err: parser.ErrorAt(end, "parse", expected("value, found %q", parser.CurrToken().Char()))}
+		logError(parser.Logger(), "failed to new", "error", err)
+		return nil, err
 	}
 
-	if shortPattern.Match(b) {
-		return new(ShortPayload), nil
+	// NOTE: a value followed by ':' is the value and the key of the next tag, the value ends where Compact removed whitespace
+	if parser.CurrToken().Char() == ':' {
+		gap := parser.Gap(start, end)
+		err := &NbtError{Op: "new", Err: parser.ErrorAt(gap, "parse", expected("',' after value %q", b[:gap-start]))}
+		logError(parser.Logger(), "failed to new", "error", err)
+		return nil, err
 	}
 
-	if intPattern.Match(b) {
-		return new(IntPayload), nil
-	}
+	if operationPattern.Match(b) {
+		v, err := evalOperation(b)
+		if err != nil {
+			err = &NbtError{Op: "new", Err: err}
+			logError(parser.Logger(), "failed to new", "error", err)
+			return nil, err
+		}
 
-	if longPattern.Match(b) {
-		return new(LongPayload), nil
+		payload, _ := NewPayload(v.TypeId())
+
+		return payload.(snbtPayload), nil
 	}
 
-	if floatPattern.Match(b) {
-		return new(FloatPayload), nil
+	if booleanPattern.Match(b) {
+		return new(BytePayload), nil
 	}
 
-	if doublePattern.Match(b) {
-		return new(DoublePayload), nil
+	if n, ok := scanNumber(b); ok {
+		payload, err := NewPayload(n.typ)
+		if err != nil {
+			err = &NbtError{Op: "new", Err: err}
+			logError(parser.Logger(), "failed to new", "error", err)
+			return nil, err
+		}
+
+		return payload.(snbtPayload), nil
 	}
 
 	return new(StringPayload), nil
@@ -21,7 +21,6 @@
 package nbt
 
 import (
-	"encoding/binary"
 	"fmt"
 	"io"
 	"strconv"
@@ -37,59 +36,90 @@ func NewBytePayload(value int8) *BytePayload {
 }
 
 func (p BytePayload) String() string {
-	return p.stringify(" ", "", 0)
+	return string(p.AppendSNBT(nil, &StringifyOptions{Space: " "}, 0))
 }
 
 func (p *BytePayload) TypeId() TagType {
 	return TagTypeByte
 }
 
-func (p *BytePayload) encode(w io.Writer) error {
-	if err := binary.Write(w, binary.BigEndian, p); err != nil {
-		err = &NbtError{Op: "encode", Err: err}
-		logger.Println("failed to encode", "func", getFuncName(), "payload", p, "error", err)
+func (p *BytePayload) EncodeNBT(w io.Writer, opts *EncodeOptions) error {
+	if err := writeInt8(w, int8(*p)); err != nil {
+		logError(opts.logger(), "failed to encode", "payload", p, "error", err)
 		return err
 	}
 
 	return nil
 }
 
-func (p *BytePayload) decode(r io.Reader) error {
-	payload := new(BytePayload)
-	if err := binary.Read(r, binary.BigEndian, payload); err != nil {
-		err = &NbtError{Op: "decode", Err: err}
-		logger.Println("failed to decode", "func", getFuncName(), "payload", p, "error", err)
+func (p *BytePayload) AppendNBT(dst []byte, opts *EncodeOptions) ([]byte, error) {
+	return appendInt8(dst, int8(*p)), nil
+}
+
+func (p *BytePayload) EncodedSize(opts *EncodeOptions) int {
+	return 1
+}
+
+func (p *BytePayload) DecodeNBT(r io.Reader, opts *DecodeOptions) error {
+	br := asByteReader(r, opts.logger())
+
+	v, err := readInt8(br)
+	if err != nil {
+		logError(opts.logger(), "failed to decode", "payload", p, "error", err)
 		return err
 	}
 
-	*p = *payload
+	*p = BytePayload(v)
 
 	return nil
 }
 
-func (p *BytePayload) stringify(space string, indent string, depth int) string {
-	return fmt.Sprintf("%db", *p)
+func (p *BytePayload) AppendSNBT(dst []byte, opts *StringifyOptions, depth int) []byte {
+	if opts == nil {
+		opts = new(StringifyOptions)
+	}
+
+	if opts.Booleans == BooleanStyleAll {
+		if b, ok := appendBoolean(dst, int8(*p)); ok {
+			return b
+		}
+	}
+
+	return append(strconv.AppendInt(dst, int64(*p), 10), 'b')
 }
 
 func (p *BytePayload) parse(parser *snbt.Parser) error {
+	if ok, err := parseOperation(parser, p); ok {
+		if err != nil {
+			err = &NbtError{Op: "parse", Err: err}
+			logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
+			return err
+		}
+
+		return nil
+	}
+
 	b, err := parser.Slice(parser.PrevToken().Index()+1, parser.CurrToken().Index())
 	if err != nil {
 		err = &NbtError{Op: "parse", Err: err}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+		logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 		return err
 	}
 
-	g := bytePattern.FindSubmatch(b)
-	if len(g) < 2 {
-		err = &NbtError{Op: "parse", Err: ErrInvalidSnbtFormat}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
-		return err
+	if g := booleanPattern.FindSubmatch(b); g != nil {
+		if len(g[1]) > 0 {
+			*p = *NewBytePayload(1)
+		} else {
+			*p = *NewBytePayload(0)
+		}
+
+		return nil
 	}
 
-	i, err := strconv.ParseInt(string(g[1]), 10, 8)
+	i, err := parseInteger(b, TagTypeByte)
 	if err != nil {
 		err = &NbtError{Op: "parse", Err: err}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+		logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 		return err
 	}
 
@@ -98,6 +128,6 @@ func (p *BytePayload) parse(parser *snbt.Parser) error {
 	return nil
 }
 
-func (p *BytePayload) json(space string, indent string, depth int) string {
-	return fmt.Sprintf("%d", *p)
+func (p *BytePayload) AppendJSON(dst []byte, opts *JsonOptions, depth int) []byte {
+	return append(dst, fmt.Sprintf("%d", *p)...)
 }
@@ -21,10 +21,8 @@
 package nbt
 
 import (
-	"encoding/binary"
 	"fmt"
 	"io"
-	"strconv"
 	"strings"
 
 	"github.com/Aton-Kish/gonbt/pointer"
@@ -42,74 +40,86 @@ func NewByteArrayPayload(values ...int8) *ByteArrayPayload {
 }
 
 func (p ByteArrayPayload) String() string {
-	return p.stringify(" ", "", 0)
+	return string(p.AppendSNBT(nil, &StringifyOptions{Space: " "}, 0))
 }
 
 func (p *ByteArrayPayload) TypeId() TagType {
 	return TagTypeByteArray
 }
 
-func (p *ByteArrayPayload) encode(w io.Writer) error {
-	l := int32(len(*p))
-	if err := binary.Write(w, binary.BigEndian, &l); err != nil {
-		err = &NbtError{Op: "encode", Err: err}
-		logger.Println("failed to encode", "func", getFuncName(), "payload", p, "error", err)
+func (p *ByteArrayPayload) EncodeNBT(w io.Writer, opts *EncodeOptions) error {
+	if err := writeLength(w, len(*p)); err != nil {
+		logError(opts.logger(), "failed to encode", "payload", p, "error", err)
 		return err
 	}
 
-	if err := binary.Write(w, binary.BigEndian, p); err != nil {
-		err = &NbtError{Op: "encode", Err: err}
-		logger.Println("failed to encode", "func", getFuncName(), "payload", p, "error", err)
+	if err := writeInt8s(w, []int8(*p)); err != nil {
+		logError(opts.logger(), "failed to encode", "payload", p, "error", err)
 		return err
 	}
 
 	return nil
 }
 
-func (p *ByteArrayPayload) decode(r io.Reader) error {
-	var l int32
-	if err := binary.Read(r, binary.BigEndian, &l); err != nil {
-		err = &NbtError{Op: "decode", Err: err}
-		logger.Println("failed to decode", "func", getFuncName(), "payload", p, "error", err)
+func (p *ByteArrayPayload) AppendNBT(dst []byte, opts *EncodeOptions) ([]byte, error) {
+	dst = appendLength(dst, len(*p))
+	return appendInt8s(dst, *p), nil
+}
+
+func (p *ByteArrayPayload) EncodedSize(opts *EncodeOptions) int {
+	return 4 + len(*p)
+}
+
+func (p *ByteArrayPayload) DecodeNBT(r io.Reader, opts *DecodeOptions) error {
+	if opts == nil {
+		opts = new(DecodeOptions)
+	}
+
+	br := asByteReader(r, opts.logger())
+
+	l, err := readLength(br)
+	if err != nil {
+		logError(opts.logger(), "failed to decode", "payload", p, "error", err)
+		return err
+	}
+
+	if err := br.ensure(l, 1); err != nil {
+		logError(opts.logger(), "failed to decode", "payload", p, "error", err)
 		return err
 	}
 
-	*p = make(ByteArrayPayload, l)
-	if err := binary.Read(r, binary.BigEndian, p); err != nil {
-		err = &NbtError{Op: "decode", Err: err}
-		logger.Println("failed to decode", "func", getFuncName(), "payload", p, "error", err)
+	values, err := readArray(br, l, opts.Arrays.int8s, readInt8s)
+	if err != nil {
+		logError(opts.logger(), "failed to decode", "payload", p, "error", err)
 		return err
 	}
 
+	*p = values
+
 	return nil
 }
 
-func (p *ByteArrayPayload) stringify(space string, indent string, depth int) string {
-	strs := make([]string, 0, len(*p))
-	for _, v := range *p {
-		strs = append(strs, fmt.Sprintf("%db", v))
-	}
-
-	return fmt.Sprintf("[B;%s%s]", space, strings.Join(strs, fmt.Sprintf(",%s", space)))
+func (p *ByteArrayPayload) AppendSNBT(dst []byte, opts *StringifyOptions, depth int) []byte {
+	return appendSNBT(dst, p, opts, depth)
 }
 
 func (p *ByteArrayPayload) parse(parser *snbt.Parser) error {
 	if err := parser.Next(); err != nil {
 		err = &NbtError{Op: "parse", Err: err}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+		logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 		return err
 	}
 
 	if parser.CurrToken().Char() != ';' {
-		err := &NbtError{Op: "parse", Err: ErrInvalidSnbtFormat}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+		err := &NbtError{Op: "parse", Err: parser.ErrorAt(parser.PrevToken().Index()+2, "parse", expected("';' after %q", "[B"))}
+		logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 		return err
 	}
 
 	for parser.CurrToken().Char() != ']' {
 		if err := parser.Next(); err != nil {
 			err = &NbtError{Op: "parse", Err: err}
-			logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+			logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 			return err
 		}
 
@@ -122,21 +132,14 @@ func (p *ByteArrayPayload) parse(parser *snbt.Parser) error {
 		b, err := parser.Slice(parser.PrevToken().Index()+1, parser.CurrToken().Index())
 		if err != nil {
 			err = &NbtError{Op: "parse", Err: err}
-			logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+			logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 			return err
 		}
 
-		g := bytePattern.FindSubmatch(b)
-		if len(g) < 2 {
-			err = &NbtError{Op: "parse", Err: ErrInvalidSnbtFormat}
-			logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
-			return err
-		}
-
-		i, err := strconv.ParseInt(string(g[1]), 10, 8)
+		i, err := parseInteger(b, TagTypeByte)
 		if err != nil {
 			err = &NbtError{Op: "parse", Err: err}
-			logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+			logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 			return err
 		}
 
@@ -145,28 +148,32 @@ func (p *ByteArrayPayload) parse(parser *snbt.Parser) error {
 
 	if err := parser.Next(); err != nil {
 		err = &NbtError{Op: "parse", Err: err}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+		logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 		return err
 	}
 
 	return nil
 }
 
-func (p *ByteArrayPayload) json(space string, indent string, depth int) string {
+func (p *ByteArrayPayload) AppendJSON(dst []byte, opts *JsonOptions, depth int) []byte {
+	if opts == nil {
+		opts = new(JsonOptions)
+	}
+
 	l := len(*p)
 	strs := make([]string, 0, l)
 	for _, v := range *p {
 		strs = append(strs, fmt.Sprintf("%d", v))
 	}
 
-	if indent == "" || l == 0 {
-		return fmt.Sprintf("[%s]", strings.Join(strs, fmt.Sprintf(",%s", space)))
+	if opts.Indent == "" || l == 0 {
+		return append(dst, fmt.Sprintf("[%s]", strings.Join(strs, fmt.Sprintf(",%s", opts.Space)))...)
 	}
 
 	indents := ""
 	for i := 0; i < depth; i++ {
-		indents += indent
+		indents += opts.Indent
 	}
 
-	return fmt.Sprintf("[\n%s%s%s\n%s]", indents, indent, strings.Join(strs, fmt.Sprintf(",\n%s%s", indents, indent)), indents)
+	return append(dst, fmt.Sprintf("[\n%s%s%s\n%s]", indents, opts.Indent, strings.Join(strs, fmt.Sprintf(",\n%s%s", indents, opts.Indent)), indents)...)
 }
@@ -42,17 +42,22 @@ func NewCompoundPayload(values ...Tag) *CompoundPayload {
 }
 
 func (p CompoundPayload) String() string {
-	return p.stringify(" ", "", 0)
+	return string(p.AppendSNBT(nil, &StringifyOptions{Space: " "}, 0))
 }
 
 func (p *CompoundPayload) TypeId() TagType {
 	return TagTypeCompound
 }
 
-func (p *CompoundPayload) encode(w io.Writer) error {
-	for _, tag := range *p {
-		if err := tag.encode(w); err != nil {
-			logger.Println("failed to encode", "func", getFuncName(), "payload", p, "error", err)
+func (p *CompoundPayload) EncodeNBT(w io.Writer, opts *EncodeOptions) error {
+	for i, tag := range *p {
+		if err := opts.checkContext(i); err != nil {
+			logError(opts.logger(), "failed to encode", "payload", p, "error", err)
+			return err
+		}
+
+		if err := tag.EncodeNBT(w, opts); err != nil {
+			logError(opts.logger(), "failed to encode", "payload", p, "error", err)
 			return err
 		}
 	}
@@ -60,54 +65,82 @@ func (p *CompoundPayload) encode(w io.Writer) error {
 	return nil
 }
 
-func (p *CompoundPayload) decode(r io.Reader) error {
-	for {
-		tag, err := Decode(r)
-		if err != nil {
-			logger.Println("failed to decode", "func", getFuncName(), "payload", p, "error", err)
-			return err
+func (p *CompoundPayload) AppendNBT(dst []byte, opts *EncodeOptions) ([]byte, error) {
+	for i, tag := range *p {
+		if err := opts.checkContext(i); err != nil {
+			logError(opts.logger(), "failed to encode", "payload", p, "error", err)
+			return nil, err
 		}
 
-		*p = append(*p, tag)
-
-		if tag.TypeId() == TagTypeEnd {
-			break
+		var err error
+		dst, err = appendNBT(dst, tag, opts)
+		if err != nil {
+			logError(opts.logger(), "failed to encode", "payload", p, "error", err)
+			return nil, err
 		}
 	}
 
-	return nil
+	return dst, nil
 }
 
-func (p *CompoundPayload) stringify(space string, indent string, depth int) string {
-	strs := make([]string, 0, len(*p))
+func (p *CompoundPayload) EncodedSize(opts *EncodeOptions) int {
+	size := 0
 	for _, tag := range *p {
-		if tag.TypeId() == TagTypeEnd {
-			break
-		}
-
-		strs = append(strs, tag.stringify(space, indent, depth+1))
+		size += encodedSize(tag, opts)
 	}
 
-	l := len(strs)
-	sort.SliceStable(strs, func(i, j int) bool { return strs[i] < strs[j] })
+	return size
+}
 
-	if indent == "" || l == 0 {
-		return fmt.Sprintf("{%s}", strings.Join(strs, fmt.Sprintf(",%s", space)))
+func (p *CompoundPayload) DecodeNBT(r io.Reader, opts *DecodeOptions) error {
+	if opts == nil {
+		opts = new(DecodeOptions)
 	}
 
-	indents := ""
-	for i := 0; i < depth; i++ {
-		indents += indent
+	br := asByteReader(r, opts.logger())
+
+	for i := 0; ; i++ {
+		if err := opts.checkContext(i); err != nil {
+			logError(opts.logger(), "failed to decode", "payload", p, "error", err)
+			return err
+		}
+
+		var tag Tag
+		var err error
+		if opts.selection == nil {
+			tag, err = decodeTag(br, opts, opts.Lazy)
+		} else {
+			tag, err = decodeSelectedTag(br, opts)
+		}
+
+		if err != nil {
+			logError(opts.logger(), "failed to decode", "payload", p, "error", err)
+			return err
+		}
+
+		if tag == nil {
+			continue
+		}
+
+		*p = append(*p, tag)
+
+		if tag.TypeId() == TagTypeEnd {
+			break
+		}
 	}
 
-	return fmt.Sprintf("{\n%s%s%s\n%s}", indents, indent, strings.Join(strs, fmt.Sprintf(",\n%s%s", indents, indent)), indents)
+	return nil
+}
+
+func (p *CompoundPayload) AppendSNBT(dst []byte, opts *StringifyOptions, depth int) []byte {
+	return appendSNBT(dst, p, opts, depth)
 }
 
 func (p *CompoundPayload) parse(parser *snbt.Parser) error {
 	for parser.CurrToken().Char() != '}' {
 		if err := parser.Next(); err != nil {
 			err = &NbtError{Op: "parse", Err: err}
-			logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+			logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 			return err
 		}
 
@@ -120,12 +153,17 @@ func (p *CompoundPayload) parse(parser *snbt.Parser) error {
 		tag, err := newTagFromSnbt(parser)
 		if err != nil {
 			err = &NbtError{Op: "parse", Err: err}
-			logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+			logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 			return err
 		}
 
 		if err := tag.parse(parser); err != nil {
-			logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+			logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
+			return err
+		}
+
+		if err := expectSeparator(parser, '}'); err != nil {
+			logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 			return err
 		}
 
@@ -137,34 +175,38 @@ func (p *CompoundPayload) parse(parser *snbt.Parser) error {
 	// NOTE: ignore stop iteration error
 	if err := parser.Next(); err != nil && !errors.Is(err, snbt.ErrStopIteration) {
 		err = &NbtError{Op: "parse", Err: err}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+		logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 		return err
 	}
 
 	return nil
 }
 
-func (p *CompoundPayload) json(space string, indent string, depth int) string {
+func (p *CompoundPayload) AppendJSON(dst []byte, opts *JsonOptions, depth int) []byte {
+	if opts == nil {
+		opts = new(JsonOptions)
+	}
+
 	strs := make([]string, 0, len(*p))
 	for _, tag := range *p {
 		if tag.TypeId() == TagTypeEnd {
 			break
 		}
 
-		strs = append(strs, tag.json(space, indent, depth+1))
+		strs = append(strs, string(tag.AppendJSON(nil, opts, depth+1)))
 	}
 
 	l := len(strs)
 	sort.SliceStable(strs, func(i, j int) bool { return strs[i] < strs[j] })
 
-	if indent == "" || l == 0 {
-		return fmt.Sprintf("{%s}", strings.Join(strs, fmt.Sprintf(",%s", space)))
+	if opts.Indent == "" || l == 0 {
+		return append(dst, fmt.Sprintf("{%s}", strings.Join(strs, fmt.Sprintf(",%s", opts.Space)))...)
 	}
 
 	indents := ""
 	for i := 0; i < depth; i++ {
-		indents += indent
+		indents += opts.Indent
 	}
 
-	return fmt.Sprintf("{\n%s%s%s\n%s}", indents, indent, strings.Join(strs, fmt.Sprintf(",\n%s%s", indents, indent)), indents)
+	return append(dst, fmt.Sprintf("{\n%s%s%s\n%s}", indents, opts.Indent, strings.Join(strs, fmt.Sprintf(",\n%s%s", indents, opts.Indent)), indents)...)
 }
@@ -60,7 +60,7 @@ func TestCompoundPayload_TypeId(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
-func TestCompoundPayload_encode(t *testing.T) {
+func TestCompoundPayload_EncodeNBT(t *testing.T) {
 	type Case struct {
 		name        string
 		payload     *CompoundPayload
@@ -82,7 +82,7 @@ func TestCompoundPayload_encode(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			buf := new(bytes.Buffer)
-			err := tt.payload.encode(buf)
+			err := tt.payload.EncodeNBT(buf, new(EncodeOptions))
 
 			if tt.expectedErr == nil {
 				assert.NoError(t, err)
@@ -95,7 +95,67 @@ func TestCompoundPayload_encode(t *testing.T) {
 	}
 }
 
-func TestCompoundPayload_decode(t *testing.T) {
+func TestCompoundPayload_AppendNBT(t *testing.T) {
+	type Case struct {
+		name        string
+		payload     *CompoundPayload
+		expected    []byte
+		expectedErr error
+	}
+
+	cases := []Case{}
+
+	for _, c := range compoundTagCases {
+		cases = append(cases, Case{
+			name:        c.name,
+			payload:     c.nbt.payload,
+			expected:    c.raw.payload,
+			expectedErr: nil,
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix := []byte{0xFF}
+			actual, err := tt.payload.AppendNBT(prefix, new(EncodeOptions))
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, append(prefix, tt.expected...), actual)
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestCompoundPayload_EncodedSize(t *testing.T) {
+	type Case struct {
+		name     string
+		payload  *CompoundPayload
+		expected int
+	}
+
+	cases := []Case{}
+
+	for _, c := range compoundTagCases {
+		cases = append(cases, Case{
+			name:     c.name,
+			payload:  c.nbt.payload,
+			expected: len(c.raw.payload),
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.payload.EncodedSize(new(EncodeOptions))
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestCompoundPayload_DecodeNBT(t *testing.T) {
 	type Case struct {
 		name        string
 		raw         []byte
@@ -119,7 +179,7 @@ func TestCompoundPayload_decode(t *testing.T) {
 			buf := bytes.NewBuffer(tt.raw)
 
 			payload := new(CompoundPayload)
-			err := payload.decode(buf)
+			err := payload.DecodeNBT(buf, new(DecodeOptions))
 
 			if tt.expectedErr == nil {
 				assert.NoError(t, err)
@@ -132,7 +192,7 @@ func TestCompoundPayload_decode(t *testing.T) {
 	}
 }
 
-func TestCompoundPayload_stringify_default(t *testing.T) {
+func TestCompoundPayload_AppendSNBT_default(t *testing.T) {
 	type Case struct {
 		name     string
 		payload  *CompoundPayload
@@ -151,13 +211,13 @@ func TestCompoundPayload_stringify_default(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := tt.payload.stringify(" ", "", 0)
+			actual := string(tt.payload.AppendSNBT(nil, &StringifyOptions{Space: " "}, 0))
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
 }
 
-func TestCompoundPayload_stringify_compact(t *testing.T) {
+func TestCompoundPayload_AppendSNBT_compact(t *testing.T) {
 	type Case struct {
 		name     string
 		payload  *CompoundPayload
@@ -176,13 +236,13 @@ func TestCompoundPayload_stringify_compact(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := tt.payload.stringify("", "", 0)
+			actual := string(tt.payload.AppendSNBT(nil, &StringifyOptions{}, 0))
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
 }
 
-func TestCompoundPayload_stringify_pretty(t *testing.T) {
+func TestCompoundPayload_AppendSNBT_pretty(t *testing.T) {
 	type Case struct {
 		name     string
 		payload  *CompoundPayload
@@ -201,7 +261,7 @@ func TestCompoundPayload_stringify_pretty(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := tt.payload.stringify(" ", "  ", 0)
+			actual := string(tt.payload.AppendSNBT(nil, &StringifyOptions{Space: " ", Indent: "  "}, 0))
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
@@ -258,7 +318,7 @@ func TestCompoundPayload_parse(t *testing.T) {
 	}
 }
 
-func TestCompoundPayload_json_default(t *testing.T) {
+func TestCompoundPayload_AppendJSON_default(t *testing.T) {
 	type Case struct {
 		name     string
 		payload  *CompoundPayload
@@ -277,13 +337,13 @@ func TestCompoundPayload_json_default(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := tt.payload.json(" ", "", 0)
+			actual := string(tt.payload.AppendJSON(nil, &JsonOptions{Space: " "}, 0))
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
 }
 
-func TestCompoundPayload_json_compact(t *testing.T) {
+func TestCompoundPayload_AppendJSON_compact(t *testing.T) {
 	type Case struct {
 		name     string
 		payload  *CompoundPayload
@@ -302,13 +362,13 @@ func TestCompoundPayload_json_compact(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := tt.payload.json("", "", 0)
+			actual := string(tt.payload.AppendJSON(nil, &JsonOptions{}, 0))
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
 }
 
-func TestCompoundPayload_json_pretty(t *testing.T) {
+func TestCompoundPayload_AppendJSON_pretty(t *testing.T) {
 	type Case struct {
 		name     string
 		payload  *CompoundPayload
@@ -327,7 +387,7 @@ func TestCompoundPayload_json_pretty(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := tt.payload.json(" ", "  ", 0)
+			actual := string(tt.payload.AppendJSON(nil, &JsonOptions{Space: " ", Indent: "  "}, 0))
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
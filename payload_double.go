@@ -21,10 +21,7 @@
 package nbt
 
 import (
-	"encoding/binary"
-	"fmt"
 	"io"
-	"strconv"
 
 	"github.com/Aton-Kish/gonbt/pointer"
 	"github.com/Aton-Kish/gonbt/snbt"
@@ -37,59 +34,60 @@ func NewDoublePayload(value float64) *DoublePayload {
 }
 
 func (p DoublePayload) String() string {
-	return p.stringify(" ", "", 0)
+	return string(p.AppendSNBT(nil, &StringifyOptions{Space: " "}, 0))
 }
 
 func (p *DoublePayload) TypeId() TagType {
 	return TagTypeDouble
 }
 
-func (p *DoublePayload) encode(w io.Writer) error {
-	if err := binary.Write(w, binary.BigEndian, p); err != nil {
-		err = &NbtError{Op: "encode", Err: err}
-		logger.Println("failed to encode", "func", getFuncName(), "payload", p, "error", err)
+func (p *DoublePayload) EncodeNBT(w io.Writer, opts *EncodeOptions) error {
+	if err := writeFloat64(w, float64(*p)); err != nil {
+		logError(opts.logger(), "failed to encode", "payload", p, "error", err)
 		return err
 	}
 
 	return nil
 }
 
-func (p *DoublePayload) decode(r io.Reader) error {
-	payload := new(DoublePayload)
-	if err := binary.Read(r, binary.BigEndian, payload); err != nil {
-		err = &NbtError{Op: "decode", Err: err}
-		logger.Println("failed to decode", "func", getFuncName(), "payload", p, "error", err)
+func (p *DoublePayload) AppendNBT(dst []byte, opts *EncodeOptions) ([]byte, error) {
+	return appendFloat64(dst, float64(*p)), nil
+}
+
+func (p *DoublePayload) EncodedSize(opts *EncodeOptions) int {
+	return 8
+}
+
+func (p *DoublePayload) DecodeNBT(r io.Reader, opts *DecodeOptions) error {
+	br := asByteReader(r, opts.logger())
+
+	v, err := readFloat64(br)
+	if err != nil {
+		logError(opts.logger(), "failed to decode", "payload", p, "error", err)
 		return err
 	}
 
-	*p = *payload
+	*p = DoublePayload(v)
 
 	return nil
 }
 
-func (p *DoublePayload) stringify(space string, indent string, depth int) string {
-	return fmt.Sprintf("%gd", *p)
+func (p *DoublePayload) AppendSNBT(dst []byte, opts *StringifyOptions, depth int) []byte {
+	return appendSnbtFloat(dst, float64(*p), 64, 'd')
 }
 
 func (p *DoublePayload) parse(parser *snbt.Parser) error {
 	b, err := parser.Slice(parser.PrevToken().Index()+1, parser.CurrToken().Index())
 	if err != nil {
 		err = &NbtError{Op: "parse", Err: err}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+		logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 		return err
 	}
 
-	g := doublePattern.FindSubmatch(b)
-	if len(g) < 2 {
-		err = &NbtError{Op: "parse", Err: ErrInvalidSnbtFormat}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
-		return err
-	}
-
-	f, err := strconv.ParseFloat(string(g[1]), 64)
+	f, err := parseFloat(b, TagTypeDouble)
 	if err != nil {
 		err = &NbtError{Op: "parse", Err: err}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+		logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 		return err
 	}
 
@@ -98,6 +96,10 @@ func (p *DoublePayload) parse(parser *snbt.Parser) error {
 	return nil
 }
 
-func (p *DoublePayload) json(space string, indent string, depth int) string {
-	return fmt.Sprintf("%g", *p)
+func (p *DoublePayload) AppendJSON(dst []byte, opts *JsonOptions, depth int) []byte {
+	if opts == nil {
+		opts = new(JsonOptions)
+	}
+
+	return opts.appendFloat(dst, float64(*p), 64)
 }
@@ -21,10 +21,7 @@
 package nbt
 
 import (
-	"encoding/binary"
-	"fmt"
 	"io"
-	"strconv"
 
 	"github.com/Aton-Kish/gonbt/pointer"
 	"github.com/Aton-Kish/gonbt/snbt"
@@ -37,59 +34,60 @@ func NewFloatPayload(value float32) *FloatPayload {
 }
 
 func (p FloatPayload) String() string {
-	return p.stringify(" ", "", 0)
+	return string(p.AppendSNBT(nil, &StringifyOptions{Space: " "}, 0))
 }
 
 func (p *FloatPayload) TypeId() TagType {
 	return TagTypeFloat
 }
 
-func (p *FloatPayload) encode(w io.Writer) error {
-	if err := binary.Write(w, binary.BigEndian, p); err != nil {
-		err = &NbtError{Op: "encode", Err: err}
-		logger.Println("failed to encode", "func", getFuncName(), "payload", p, "error", err)
+func (p *FloatPayload) EncodeNBT(w io.Writer, opts *EncodeOptions) error {
+	if err := writeFloat32(w, float32(*p)); err != nil {
+		logError(opts.logger(), "failed to encode", "payload", p, "error", err)
 		return err
 	}
 
 	return nil
 }
 
-func (p *FloatPayload) decode(r io.Reader) error {
-	payload := new(FloatPayload)
-	if err := binary.Read(r, binary.BigEndian, payload); err != nil {
-		err = &NbtError{Op: "decode", Err: err}
-		logger.Println("failed to decode", "func", getFuncName(), "payload", p, "error", err)
+func (p *FloatPayload) AppendNBT(dst []byte, opts *EncodeOptions) ([]byte, error) {
+	return appendFloat32(dst, float32(*p)), nil
+}
+
+func (p *FloatPayload) EncodedSize(opts *EncodeOptions) int {
+	return 4
+}
+
+func (p *FloatPayload) DecodeNBT(r io.Reader, opts *DecodeOptions) error {
+	br := asByteReader(r, opts.logger())
+
+	v, err := readFloat32(br)
+	if err != nil {
+		logError(opts.logger(), "failed to decode", "payload", p, "error", err)
 		return err
 	}
 
-	*p = *payload
+	*p = FloatPayload(v)
 
 	return nil
 }
 
-func (p *FloatPayload) stringify(space string, indent string, depth int) string {
-	return fmt.Sprintf("%gf", *p)
+func (p *FloatPayload) AppendSNBT(dst []byte, opts *StringifyOptions, depth int) []byte {
+	return appendSnbtFloat(dst, float64(*p), 32, 'f')
 }
 
 func (p *FloatPayload) parse(parser *snbt.Parser) error {
 	b, err := parser.Slice(parser.PrevToken().Index()+1, parser.CurrToken().Index())
 	if err != nil {
 		err = &NbtError{Op: "parse", Err: err}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+		logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 		return err
 	}
 
-	g := floatPattern.FindSubmatch(b)
-	if len(g) < 2 {
-		err = &NbtError{Op: "parse", Err: ErrInvalidSnbtFormat}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
-		return err
-	}
-
-	f, err := strconv.ParseFloat(string(g[1]), 32)
+	f, err := parseFloat(b, TagTypeFloat)
 	if err != nil {
 		err = &NbtError{Op: "parse", Err: err}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+		logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 		return err
 	}
 
@@ -98,6 +96,10 @@ func (p *FloatPayload) parse(parser *snbt.Parser) error {
 	return nil
 }
 
-func (p *FloatPayload) json(space string, indent string, depth int) string {
-	return fmt.Sprintf("%g", *p)
+func (p *FloatPayload) AppendJSON(dst []byte, opts *JsonOptions, depth int) []byte {
+	if opts == nil {
+		opts = new(JsonOptions)
+	}
+
+	return opts.appendFloat(dst, float64(*p), 32)
 }
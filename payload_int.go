@@ -21,7 +21,6 @@
 package nbt
 
 import (
-	"encoding/binary"
 	"fmt"
 	"io"
 	"strconv"
@@ -37,52 +36,60 @@ func NewIntPayload(value int32) *IntPayload {
 }
 
 func (p IntPayload) String() string {
-	return p.stringify(" ", "", 0)
+	return string(p.AppendSNBT(nil, &StringifyOptions{Space: " "}, 0))
 }
 
 func (p *IntPayload) TypeId() TagType {
 	return TagTypeInt
 }
 
-func (p *IntPayload) encode(w io.Writer) error {
-	if err := binary.Write(w, binary.BigEndian, p); err != nil {
-		err = &NbtError{Op: "encode", Err: err}
-		logger.Println("failed to encode", "func", getFuncName(), "payload", p, "error", err)
+func (p *IntPayload) EncodeNBT(w io.Writer, opts *EncodeOptions) error {
+	if err := writeInt32(w, int32(*p)); err != nil {
+		logError(opts.logger(), "failed to encode", "payload", p, "error", err)
 		return err
 	}
 
 	return nil
 }
 
-func (p *IntPayload) decode(r io.Reader) error {
-	payload := new(IntPayload)
-	if err := binary.Read(r, binary.BigEndian, payload); err != nil {
-		err = &NbtError{Op: "decode", Err: err}
-		logger.Println("failed to decode", "func", getFuncName(), "payload", p, "error", err)
+func (p *IntPayload) AppendNBT(dst []byte, opts *EncodeOptions) ([]byte, error) {
+	return appendInt32(dst, int32(*p)), nil
+}
+
+func (p *IntPayload) EncodedSize(opts *EncodeOptions) int {
+	return 4
+}
+
+func (p *IntPayload) DecodeNBT(r io.Reader, opts *DecodeOptions) error {
+	br := asByteReader(r, opts.logger())
+
+	v, err := readInt32(br)
+	if err != nil {
+		logError(opts.logger(), "failed to decode", "payload", p, "error", err)
 		return err
 	}
 
-	*p = *payload
+	*p = IntPayload(v)
 
 	return nil
 }
 
-func (p *IntPayload) stringify(space string, indent string, depth int) string {
-	return fmt.Sprintf("%d", *p)
+func (p *IntPayload) AppendSNBT(dst []byte, opts *StringifyOptions, depth int) []byte {
+	return strconv.AppendInt(dst, int64(*p), 10)
 }
 
 func (p *IntPayload) parse(parser *snbt.Parser) error {
 	b, err := parser.Slice(parser.PrevToken().Index()+1, parser.CurrToken().Index())
 	if err != nil {
 		err = &NbtError{Op: "parse", Err: err}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+		logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 		return err
 	}
 
-	i, err := strconv.ParseInt(string(b), 10, 32)
+	i, err := parseInteger(b, TagTypeInt)
 	if err != nil {
 		err = &NbtError{Op: "parse", Err: err}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+		logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 		return err
 	}
 
@@ -91,6 +98,6 @@ func (p *IntPayload) parse(parser *snbt.Parser) error {
 	return nil
 }
 
-func (p *IntPayload) json(space string, indent string, depth int) string {
-	return fmt.Sprintf("%d", *p)
+func (p *IntPayload) AppendJSON(dst []byte, opts *JsonOptions, depth int) []byte {
+	return append(dst, fmt.Sprintf("%d", *p)...)
 }
@@ -21,56 +21,112 @@
 package nbt
 
 import (
-	"encoding/binary"
 	"fmt"
 	"io"
+	"log/slog"
 	"strings"
 
-	"github.com/Aton-Kish/gonbt/pointer"
 	"github.com/Aton-Kish/gonbt/snbt"
 )
 
-type ListPayload []Payload
+// NOTE: elemType is the declared element type, which is encoded while the list is empty, unwrapped is set when decoding with UnwrapLists left a wrapped list homogeneous
+type ListPayload struct {
+	elemType  TagType
+	values    []Payload
+	unwrapped bool
+}
 
 func NewListPayload(values ...Payload) *ListPayload {
-	if values == nil {
-		values = []Payload{}
-	}
+	p := new(ListPayload)
+	p.SetValues(values...)
+
+	return p
+}
 
-	return pointer.Pointer(ListPayload(values))
+func NewEmptyListPayload(typ TagType) *ListPayload {
+	return &ListPayload{elemType: typ, values: []Payload{}}
 }
 
 func (p ListPayload) String() string {
-	return p.stringify(" ", "", 0)
+	return string(p.AppendSNBT(nil, &StringifyOptions{Space: " "}, 0))
 }
 
 func (p *ListPayload) TypeId() TagType {
 	return TagTypeList
 }
 
-func (p *ListPayload) encode(w io.Writer) error {
-	l := int32(len(*p))
+func (p *ListPayload) ElemType() TagType {
+	if p.Heterogeneous() {
+		return TagTypeCompound
+	}
+
+	if len(p.values) > 0 {
+		return p.values[0].TypeId()
+	}
+
+	return p.elemType
+}
+
+func (p *ListPayload) Len() int {
+	return len(p.values)
+}
+
+// NOTE: the returned slice is shared with the list, use SetValues to change its length
+func (p *ListPayload) Values() []Payload {
+	return p.values
+}
+
+// NOTE: the element type is kept when values is empty, so that an emptied list is encoded with its last type
+func (p *ListPayload) SetValues(values ...Payload) {
+	if values == nil {
+		values = []Payload{}
+	}
+
+	p.values = values
+	p.unwrapped = false
+	p.updateElemType()
+}
+
+func (p *ListPayload) Append(values ...Payload) {
+	p.values = append(p.values, values...)
+	p.updateElemType()
+}
 
-	typ := TagTypeEnd
-	if l > 0 {
-		typ = []Payload(*p)[0].TypeId()
+// NOTE: elemType follows the first value, and is left as is when the list is empty
+func (p *ListPayload) updateElemType() {
+	if len(p.values) > 0 {
+		p.elemType = p.values[0].TypeId()
 	}
+}
 
-	if err := binary.Write(w, binary.BigEndian, &typ); err != nil {
+func (p *ListPayload) EncodeNBT(w io.Writer, opts *EncodeOptions) error {
+	p, err := p.wrapped()
+	if err != nil {
 		err = &NbtError{Op: "encode", Err: err}
-		logger.Println("failed to encode", "func", getFuncName(), "payload", p, "error", err)
+		logError(opts.logger(), "failed to encode", "payload", p, "error", err)
 		return err
 	}
 
-	if err := binary.Write(w, binary.BigEndian, &l); err != nil {
-		err = &NbtError{Op: "encode", Err: err}
-		logger.Println("failed to encode", "func", getFuncName(), "payload", p, "error", err)
+	typ := p.ElemType()
+
+	if err := writeTagType(w, typ); err != nil {
+		logError(opts.logger(), "failed to encode", "payload", p, "error", err)
 		return err
 	}
 
-	for _, payload := range *p {
-		if err := payload.encode(w); err != nil {
-			logger.Println("failed to encode", "func", getFuncName(), "payload", p, "error", err)
+	if err := writeLength(w, len(p.values)); err != nil {
+		logError(opts.logger(), "failed to encode", "payload", p, "error", err)
+		return err
+	}
+
+	for i, payload := range p.values {
+		if err := opts.checkContext(i); err != nil {
+			logError(opts.logger(), "failed to encode", "payload", p, "error", err)
+			return err
+		}
+
+		if err := payload.EncodeNBT(w, opts); err != nil {
+			logError(opts.logger(), "failed to encode", "payload", p, "error", err)
 			return err
 		}
 	}
@@ -78,65 +134,123 @@ func (p *ListPayload) encode(w io.Writer) error {
 	return nil
 }
 
-func (p *ListPayload) decode(r io.Reader) error {
-	var typ TagType
-	if err := binary.Read(r, binary.BigEndian, &typ); err != nil {
-		err = &NbtError{Op: "decode", Err: err}
-		logger.Println("failed to decode", "func", getFuncName(), "payload", p, "error", err)
+func (p *ListPayload) AppendNBT(dst []byte, opts *EncodeOptions) ([]byte, error) {
+	p, err := p.wrapped()
+	if err != nil {
+		err = &NbtError{Op: "encode", Err: err}
+		logError(opts.logger(), "failed to encode", "payload", p, "error", err)
+		return nil, err
+	}
+
+	typ := p.ElemType()
+
+	dst = appendTagType(dst, typ)
+	dst = appendLength(dst, len(p.values))
+
+	for i, payload := range p.values {
+		if err := opts.checkContext(i); err != nil {
+			logError(opts.logger(), "failed to encode", "payload", p, "error", err)
+			return nil, err
+		}
+
+		var err error
+		dst, err = appendNBT(dst, payload, opts)
+		if err != nil {
+			logError(opts.logger(), "failed to encode", "payload", p, "error", err)
+			return nil, err
+		}
+	}
+
+	return dst, nil
+}
+
+func (p *ListPayload) EncodedSize(opts *EncodeOptions) int {
+	if w, err := p.wrapped(); err == nil {
+		p = w
+	}
+
+	size := 1 + 4
+	for _, payload := range p.values {
+		size += encodedSize(payload, opts)
+	}
+
+	return size
+}
+
+func (p *ListPayload) DecodeNBT(r io.Reader, opts *DecodeOptions) error {
+	if opts == nil {
+		opts = new(DecodeOptions)
+	}
+
+	br := asByteReader(r, opts.logger())
+
+	typ, err := readTagType(br)
+	if err != nil {
+		logError(opts.logger(), "failed to decode", "payload", p, "error", err)
+		return err
+	}
+
+	l, err := readLength(br)
+	if err != nil {
+		logError(opts.logger(), "failed to decode", "payload", p, "error", err)
 		return err
 	}
 
-	var l int32
-	if err := binary.Read(r, binary.BigEndian, &l); err != nil {
-		err = &NbtError{Op: "decode", Err: err}
-		logger.Println("failed to decode", "func", getFuncName(), "payload", p, "error", err)
+	if logEnabled(opts.logger(), slog.LevelDebug) {
+		logDebug(opts.logger(), "decoding list", "type", typ.String(), "length", l)
+	}
+
+	if l == 0 {
+		*p = *NewEmptyListPayload(typ)
+		return nil
+	}
+
+	// NOTE: every payload takes at least one byte
+	if err := br.ensure(l, 1); err != nil {
+		logError(opts.logger(), "failed to decode", "payload", p, "error", err)
 		return err
 	}
 
-	*p = make([]Payload, 0, l)
-	for i := 0; i < int(l); i++ {
-		payload, err := NewPayload(typ)
+	*p = ListPayload{values: make([]Payload, 0, br.prealloc(l))}
+	for i := 0; i < l; i++ {
+		if err := opts.checkContext(i); err != nil {
+			logError(opts.logger(), "failed to decode", "payload", p, "error", err)
+			return err
+		}
+
+		payload, err := newLazyPayload(typ, opts.Lazy)
 		if err != nil {
 			err = &NbtError{Op: "decode", Err: err}
-			logger.Println("failed to decode", "func", getFuncName(), "payload", p, "error", err)
+			logError(opts.logger(), "failed to decode", "payload", p, "error", err)
 			return err
 		}
 
-		if err := payload.decode(r); err != nil {
-			logger.Println("failed to decode", "func", getFuncName(), "payload", p, "error", err)
+		if err := payload.DecodeNBT(br, opts); err != nil {
+			logError(opts.logger(), "failed to decode", "payload", p, "error", err)
 			return err
 		}
 
-		*p = append(*p, payload)
+		p.values = append(p.values, payload)
 	}
 
-	return nil
-}
-
-func (p *ListPayload) stringify(space string, indent string, depth int) string {
-	l := len(*p)
-	strs := make([]string, 0, l)
-	for _, payload := range *p {
-		strs = append(strs, payload.stringify(space, indent, depth+1))
+	if opts.UnwrapLists && typ == TagTypeCompound {
+		p.unwrap()
 	}
 
-	if indent == "" || l == 0 {
-		return fmt.Sprintf("[%s]", strings.Join(strs, fmt.Sprintf(",%s", space)))
-	}
+	p.updateElemType()
 
-	indents := ""
-	for i := 0; i < depth; i++ {
-		indents += indent
-	}
+	return nil
+}
 
-	return fmt.Sprintf("[\n%s%s%s\n%s]", indents, indent, strings.Join(strs, fmt.Sprintf(",\n%s%s", indents, indent)), indents)
+func (p *ListPayload) AppendSNBT(dst []byte, opts *StringifyOptions, depth int) []byte {
+	return appendSNBT(dst, p, opts, depth)
 }
 
 func (p *ListPayload) parse(parser *snbt.Parser) error {
 	for parser.CurrToken().Char() != ']' {
 		if err := parser.Next(); err != nil {
 			err = &NbtError{Op: "parse", Err: err}
-			logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+			logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 			return err
 		}
 
@@ -149,42 +263,53 @@ func (p *ListPayload) parse(parser *snbt.Parser) error {
 		payload, err := newPayloadFromSnbt(parser)
 		if err != nil {
 			err = &NbtError{Op: "parse", Err: err}
-			logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+			logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 			return err
 		}
 
 		if err := payload.parse(parser); err != nil {
-			logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+			logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
+			return err
+		}
+
+		if err := expectSeparator(parser, ']'); err != nil {
+			logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 			return err
 		}
 
-		*p = append(*p, payload)
+		p.values = append(p.values, payload)
 	}
 
+	p.updateElemType()
+
 	if err := parser.Next(); err != nil {
 		err = &NbtError{Op: "parse", Err: err}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+		logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 		return err
 	}
 
 	return nil
 }
 
-func (p *ListPayload) json(space string, indent string, depth int) string {
-	l := len(*p)
+func (p *ListPayload) AppendJSON(dst []byte, opts *JsonOptions, depth int) []byte {
+	if opts == nil {
+		opts = new(JsonOptions)
+	}
+
+	l := len(p.values)
 	strs := make([]string, 0, l)
-	for _, payload := range *p {
-		strs = append(strs, payload.json(space, indent, depth+1))
+	for _, payload := range p.values {
+		strs = append(strs, string(payload.AppendJSON(nil, opts, depth+1)))
 	}
 
-	if indent == "" || l == 0 {
-		return fmt.Sprintf("[%s]", strings.Join(strs, fmt.Sprintf(",%s", space)))
+	if opts.Indent == "" || l == 0 {
+		return append(dst, fmt.Sprintf("[%s]", strings.Join(strs, fmt.Sprintf(",%s", opts.Space)))...)
 	}
 
 	indents := ""
 	for i := 0; i < depth; i++ {
-		indents += indent
+		indents += opts.Indent
 	}
 
-	return fmt.Sprintf("[\n%s%s%s\n%s]", indents, indent, strings.Join(strs, fmt.Sprintf(",\n%s%s", indents, indent)), indents)
+	return append(dst, fmt.Sprintf("[\n%s%s%s\n%s]", indents, opts.Indent, strings.Join(strs, fmt.Sprintf(",\n%s%s", indents, opts.Indent)), indents)...)
 }
@@ -40,9 +40,14 @@ func TestNewListPayload(t *testing.T) {
 	cases := []Case{}
 
 	for _, c := range listTagCases {
+		// NOTE: the declared element type of an empty list is not given by its values
+		if c.nbt.payload.Len() == 0 && c.nbt.payload.ElemType() != TagTypeEnd {
+			continue
+		}
+
 		cases = append(cases, Case{
 			name:     c.name,
-			values:   []Payload(*c.nbt.payload),
+			values:   c.nbt.payload.Values(),
 			expected: c.nbt.payload,
 		})
 	}
@@ -60,7 +65,7 @@ func TestListPayload_TypeId(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
-func TestListPayload_encode(t *testing.T) {
+func TestListPayload_EncodeNBT(t *testing.T) {
 	type Case struct {
 		name        string
 		payload     *ListPayload
@@ -82,7 +87,7 @@ func TestListPayload_encode(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			buf := new(bytes.Buffer)
-			err := tt.payload.encode(buf)
+			err := tt.payload.EncodeNBT(buf, new(EncodeOptions))
 
 			if tt.expectedErr == nil {
 				assert.NoError(t, err)
@@ -95,7 +100,67 @@ func TestListPayload_encode(t *testing.T) {
 	}
 }
 
-func TestListPayload_decode(t *testing.T) {
+func TestListPayload_AppendNBT(t *testing.T) {
+	type Case struct {
+		name        string
+		payload     *ListPayload
+		expected    []byte
+		expectedErr error
+	}
+
+	cases := []Case{}
+
+	for _, c := range listTagCases {
+		cases = append(cases, Case{
+			name:        c.name,
+			payload:     c.nbt.payload,
+			expected:    c.raw.payload,
+			expectedErr: nil,
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix := []byte{0xFF}
+			actual, err := tt.payload.AppendNBT(prefix, new(EncodeOptions))
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, append(prefix, tt.expected...), actual)
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestListPayload_EncodedSize(t *testing.T) {
+	type Case struct {
+		name     string
+		payload  *ListPayload
+		expected int
+	}
+
+	cases := []Case{}
+
+	for _, c := range listTagCases {
+		cases = append(cases, Case{
+			name:     c.name,
+			payload:  c.nbt.payload,
+			expected: len(c.raw.payload),
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.payload.EncodedSize(new(EncodeOptions))
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestListPayload_DecodeNBT(t *testing.T) {
 	type Case struct {
 		name        string
 		raw         []byte
@@ -119,7 +184,7 @@ func TestListPayload_decode(t *testing.T) {
 			buf := bytes.NewBuffer(tt.raw)
 
 			payload := new(ListPayload)
-			err := payload.decode(buf)
+			err := payload.DecodeNBT(buf, new(DecodeOptions))
 
 			if tt.expectedErr == nil {
 				assert.NoError(t, err)
@@ -132,7 +197,7 @@ func TestListPayload_decode(t *testing.T) {
 	}
 }
 
-func TestListPayload_stringify_default(t *testing.T) {
+func TestListPayload_AppendSNBT_default(t *testing.T) {
 	type Case struct {
 		name     string
 		payload  *ListPayload
@@ -151,13 +216,13 @@ func TestListPayload_stringify_default(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := tt.payload.stringify(" ", "", 0)
+			actual := string(tt.payload.AppendSNBT(nil, &StringifyOptions{Space: " "}, 0))
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
 }
 
-func TestListPayload_stringify_compact(t *testing.T) {
+func TestListPayload_AppendSNBT_compact(t *testing.T) {
 	type Case struct {
 		name     string
 		payload  *ListPayload
@@ -176,13 +241,13 @@ func TestListPayload_stringify_compact(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := tt.payload.stringify("", "", 0)
+			actual := string(tt.payload.AppendSNBT(nil, &StringifyOptions{}, 0))
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
 }
 
-func TestListPayload_stringify_pretty(t *testing.T) {
+func TestListPayload_AppendSNBT_pretty(t *testing.T) {
 	type Case struct {
 		name     string
 		payload  *ListPayload
@@ -201,7 +266,7 @@ func TestListPayload_stringify_pretty(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := tt.payload.stringify(" ", "  ", 0)
+			actual := string(tt.payload.AppendSNBT(nil, &StringifyOptions{Space: " ", Indent: "  "}, 0))
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
@@ -218,6 +283,11 @@ func TestListPayload_parse(t *testing.T) {
 	cases := []Case{}
 
 	for _, c := range listTagCases {
+		// NOTE: SNBT has no element type for empty lists
+		if c.nbt.payload.Len() == 0 && c.nbt.payload.ElemType() != TagTypeEnd {
+			continue
+		}
+
 		cases = append(cases, Case{
 			name:        c.name,
 			snbt:        c.snbt.payload.typeDefault,
@@ -258,7 +328,7 @@ func TestListPayload_parse(t *testing.T) {
 	}
 }
 
-func TestListPayload_json_default(t *testing.T) {
+func TestListPayload_AppendJSON_default(t *testing.T) {
 	type Case struct {
 		name     string
 		payload  *ListPayload
@@ -277,13 +347,13 @@ func TestListPayload_json_default(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := tt.payload.json(" ", "", 0)
+			actual := string(tt.payload.AppendJSON(nil, &JsonOptions{Space: " "}, 0))
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
 }
 
-func TestListPayload_json_compact(t *testing.T) {
+func TestListPayload_AppendJSON_compact(t *testing.T) {
 	type Case struct {
 		name     string
 		payload  *ListPayload
@@ -302,13 +372,13 @@ func TestListPayload_json_compact(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := tt.payload.json("", "", 0)
+			actual := string(tt.payload.AppendJSON(nil, &JsonOptions{}, 0))
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
 }
 
-func TestListPayload_json_pretty(t *testing.T) {
+func TestListPayload_AppendJSON_pretty(t *testing.T) {
 	type Case struct {
 		name     string
 		payload  *ListPayload
@@ -327,7 +397,7 @@ func TestListPayload_json_pretty(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := tt.payload.json(" ", "  ", 0)
+			actual := string(tt.payload.AppendJSON(nil, &JsonOptions{Space: " ", Indent: "  "}, 0))
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
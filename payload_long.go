@@ -21,7 +21,6 @@
 package nbt
 
 import (
-	"encoding/binary"
 	"fmt"
 	"io"
 	"strconv"
@@ -37,59 +36,60 @@ func NewLongPayload(value int64) *LongPayload {
 }
 
 func (p LongPayload) String() string {
-	return p.stringify(" ", "", 0)
+	return string(p.AppendSNBT(nil, &StringifyOptions{Space: " "}, 0))
 }
 
 func (p *LongPayload) TypeId() TagType {
 	return TagTypeLong
 }
 
-func (p *LongPayload) encode(w io.Writer) error {
-	if err := binary.Write(w, binary.BigEndian, p); err != nil {
-		err = &NbtError{Op: "encode", Err: err}
-		logger.Println("failed to encode", "func", getFuncName(), "payload", p, "error", err)
+func (p *LongPayload) EncodeNBT(w io.Writer, opts *EncodeOptions) error {
+	if err := writeInt64(w, int64(*p)); err != nil {
+		logError(opts.logger(), "failed to encode", "payload", p, "error", err)
 		return err
 	}
 
 	return nil
 }
 
-func (p *LongPayload) decode(r io.Reader) error {
-	payload := new(LongPayload)
-	if err := binary.Read(r, binary.BigEndian, payload); err != nil {
-		err = &NbtError{Op: "decode", Err: err}
-		logger.Println("failed to decode", "func", getFuncName(), "payload", p, "error", err)
+func (p *LongPayload) AppendNBT(dst []byte, opts *EncodeOptions) ([]byte, error) {
+	return appendInt64(dst, int64(*p)), nil
+}
+
+func (p *LongPayload) EncodedSize(opts *EncodeOptions) int {
+	return 8
+}
+
+func (p *LongPayload) DecodeNBT(r io.Reader, opts *DecodeOptions) error {
+	br := asByteReader(r, opts.logger())
+
+	v, err := readInt64(br)
+	if err != nil {
+		logError(opts.logger(), "failed to decode", "payload", p, "error", err)
 		return err
 	}
 
-	*p = *payload
+	*p = LongPayload(v)
 
 	return nil
 }
 
-func (p *LongPayload) stringify(space string, indent string, depth int) string {
-	return fmt.Sprintf("%dL", *p)
+func (p *LongPayload) AppendSNBT(dst []byte, opts *StringifyOptions, depth int) []byte {
+	return append(strconv.AppendInt(dst, int64(*p), 10), 'L')
 }
 
 func (p *LongPayload) parse(parser *snbt.Parser) error {
 	b, err := parser.Slice(parser.PrevToken().Index()+1, parser.CurrToken().Index())
 	if err != nil {
 		err = &NbtError{Op: "parse", Err: err}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
-		return err
-	}
-
-	g := longPattern.FindSubmatch(b)
-	if len(g) < 2 {
-		err = &NbtError{Op: "parse", Err: ErrInvalidSnbtFormat}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+		logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 		return err
 	}
 
-	i, err := strconv.ParseInt(string(g[1]), 10, 64)
+	i, err := parseInteger(b, TagTypeLong)
 	if err != nil {
 		err = &NbtError{Op: "parse", Err: err}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+		logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 		return err
 	}
 
@@ -98,6 +98,6 @@ func (p *LongPayload) parse(parser *snbt.Parser) error {
 	return nil
 }
 
-func (p *LongPayload) json(space string, indent string, depth int) string {
-	return fmt.Sprintf("%d", *p)
+func (p *LongPayload) AppendJSON(dst []byte, opts *JsonOptions, depth int) []byte {
+	return append(dst, fmt.Sprintf("%d", *p)...)
 }
@@ -0,0 +1,152 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+)
+
+type RawPayload struct {
+	typ TagType
+	raw []byte
+}
+
+func NewRawPayload(typ TagType, raw []byte) *RawPayload {
+	return &RawPayload{
+		typ: typ,
+		raw: raw,
+	}
+}
+
+func (p RawPayload) String() string {
+	return string(p.AppendSNBT(nil, &StringifyOptions{Space: " "}, 0))
+}
+
+func (p *RawPayload) TypeId() TagType {
+	return p.typ
+}
+
+func (p *RawPayload) Bytes() []byte {
+	return p.raw
+}
+
+func (p *RawPayload) Decode(opts *DecodeOptions) (Payload, error) {
+	payload, err := NewPayload(p.typ)
+	if err != nil {
+		err = &NbtError{Op: "decode", Err: err}
+		logError(opts.logger(), "failed to decode", "payload", p, "error", err)
+		return nil, err
+	}
+
+	if err := payload.DecodeNBT(bytes.NewReader(p.raw), opts); err != nil {
+		logError(opts.logger(), "failed to decode", "payload", p, "error", err)
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+func (p *RawPayload) EncodeNBT(w io.Writer, opts *EncodeOptions) error {
+	if _, err := w.Write(p.raw); err != nil {
+		err = &NbtError{Op: "encode", Err: err}
+		logError(opts.logger(), "failed to encode", "payload", p, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (p *RawPayload) AppendNBT(dst []byte, opts *EncodeOptions) ([]byte, error) {
+	return append(dst, p.raw...), nil
+}
+
+func (p *RawPayload) EncodedSize(opts *EncodeOptions) int {
+	return len(p.raw)
+}
+
+func (p *RawPayload) DecodeNBT(r io.Reader, opts *DecodeOptions) error {
+	// NOTE: in-memory input is sliced instead of copied through a TeeReader
+	if br, ok := r.(*byteReader); ok && br.src != nil {
+		start := br.off
+		if err := skipPayloadFrom(br, p.typ, opts); err != nil {
+			logError(opts.logger(), "failed to decode", "payload", p, "error", err)
+			return err
+		}
+
+		p.raw = append([]byte(nil), br.src[start:br.off]...)
+
+		return nil
+	}
+
+	buf := new(bytes.Buffer)
+	if err := skipPayload(io.TeeReader(r, buf), p.typ, opts); err != nil {
+		logError(opts.logger(), "failed to decode", "payload", p, "error", err)
+		return err
+	}
+
+	p.raw = buf.Bytes()
+
+	return nil
+}
+
+func (p *RawPayload) AppendSNBT(dst []byte, opts *StringifyOptions, depth int) []byte {
+	payload, err := p.Decode(new(DecodeOptions))
+	if err != nil {
+		logError(opts.logger(), "failed to stringify", "error", err)
+		return append(dst, p.invalid()...)
+	}
+
+	return payload.AppendSNBT(dst, opts, depth)
+}
+
+func (p *RawPayload) AppendJSON(dst []byte, opts *JsonOptions, depth int) []byte {
+	payload, err := p.Decode(new(DecodeOptions))
+	if err != nil {
+		logError(opts.logger(), "failed to stringify", "error", err)
+		return strconv.AppendQuote(dst, p.invalid())
+	}
+
+	return payload.AppendJSON(dst, opts, depth)
+}
+
+// NOTE: stands in for raw bytes that fail to decode, SNBT gets it unquoted so that it does not parse back as a string
+func (p *RawPayload) invalid() string {
+	return "<invalid " + p.typ.String() + ">"
+}
+
+func newLazyPayload(typ TagType, lazy bool) (Payload, error) {
+	if lazy && lazyDecodable(typ) {
+		return NewRawPayload(typ, nil), nil
+	}
+
+	return NewPayload(typ)
+}
+
+func lazyDecodable(typ TagType) bool {
+	switch typ {
+	case TagTypeByteArray, TagTypeList, TagTypeCompound, TagTypeIntArray, TagTypeLongArray:
+		return true
+	default:
+		return false
+	}
+}
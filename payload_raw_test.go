@@ -0,0 +1,274 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRawPayload(t *testing.T) {
+	type Case struct {
+		name     string
+		tagType  TagType
+		raw      []byte
+		expected *RawPayload
+	}
+
+	cases := []Case{}
+
+	for _, c := range excludeEndTagCases {
+		cases = append(cases, Case{
+			name:    c.name,
+			tagType: c.nbt.tagType,
+			raw:     c.raw.payload,
+			expected: &RawPayload{
+				typ: c.nbt.tagType,
+				raw: c.raw.payload,
+			},
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := NewRawPayload(tt.tagType, tt.raw)
+			assert.Equal(t, tt.expected, actual)
+			assert.Equal(t, tt.tagType, actual.TypeId())
+			assert.Equal(t, tt.raw, actual.Bytes())
+		})
+	}
+}
+
+func TestRawPayload_Decode(t *testing.T) {
+	type Case struct {
+		name        string
+		payload     *RawPayload
+		expected    Payload
+		expectedErr error
+	}
+
+	cases := []Case{}
+
+	for _, c := range excludeEndTagCases {
+		cases = append(cases, Case{
+			name:        c.name,
+			payload:     NewRawPayload(c.nbt.tagType, c.raw.payload),
+			expected:    c.nbt.payload,
+			expectedErr: nil,
+		})
+	}
+
+	cases = append(cases, Case{
+		name:        `negative case: out of range`,
+		payload:     NewRawPayload(TagType(0x0D), []byte{}),
+		expected:    nil,
+		expectedErr: &NbtError{Op: "decode", Err: &NbtError{Op: "new", Err: ErrInvalidTagType}},
+	})
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := tt.payload.Decode(new(DecodeOptions))
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, actual)
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestRawPayload_EncodeNBT(t *testing.T) {
+	type Case struct {
+		name        string
+		payload     *RawPayload
+		expected    []byte
+		expectedErr error
+	}
+
+	cases := []Case{}
+
+	for _, c := range excludeEndTagCases {
+		cases = append(cases, Case{
+			name:        c.name,
+			payload:     NewRawPayload(c.nbt.tagType, c.raw.payload),
+			expected:    c.raw.payload,
+			expectedErr: nil,
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := tt.payload.EncodeNBT(buf, new(EncodeOptions))
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, buf.Bytes())
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestRawPayload_AppendNBT(t *testing.T) {
+	type Case struct {
+		name        string
+		payload     *RawPayload
+		expected    []byte
+		expectedErr error
+	}
+
+	cases := []Case{}
+
+	for _, c := range excludeEndTagCases {
+		cases = append(cases, Case{
+			name:        c.name,
+			payload:     NewRawPayload(c.nbt.tagType, c.raw.payload),
+			expected:    c.raw.payload,
+			expectedErr: nil,
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix := []byte{0xFF}
+			actual, err := tt.payload.AppendNBT(prefix, new(EncodeOptions))
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, append(prefix, tt.expected...), actual)
+				assert.Equal(t, len(tt.expected), tt.payload.EncodedSize(new(EncodeOptions)))
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestRawPayload_DecodeNBT(t *testing.T) {
+	type Case struct {
+		name        string
+		tagType     TagType
+		raw         []byte
+		expected    *RawPayload
+		expectedErr error
+	}
+
+	cases := []Case{}
+
+	for _, c := range excludeEndTagCases {
+		cases = append(cases, Case{
+			name:        c.name,
+			tagType:     c.nbt.tagType,
+			raw:         c.raw.payload,
+			expected:    NewRawPayload(c.nbt.tagType, c.raw.payload),
+			expectedErr: nil,
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := bytes.NewBuffer(tt.raw)
+
+			payload := NewRawPayload(tt.tagType, nil)
+			err := payload.DecodeNBT(buf, new(DecodeOptions))
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.TypeId(), payload.TypeId())
+				assert.Equal(t, tt.expected.Bytes(), payload.Bytes())
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestRawPayload_AppendSNBT(t *testing.T) {
+	type Case struct {
+		name     string
+		payload  *RawPayload
+		expected string
+	}
+
+	cases := []Case{}
+
+	for _, c := range excludeEndTagCases {
+		cases = append(cases, Case{
+			name:     c.name,
+			payload:  NewRawPayload(c.nbt.tagType, c.raw.payload),
+			expected: c.snbt.payload.typeDefault,
+		})
+	}
+
+	cases = append(cases, Case{
+		name:     `negative case: truncated`,
+		payload:  NewRawPayload(TagTypeString, []byte{0x00, 0x05, 'a'}),
+		expected: `<invalid String>`,
+	})
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := string(tt.payload.AppendSNBT(nil, &StringifyOptions{Space: " "}, 0))
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestRawPayload_AppendJSON(t *testing.T) {
+	type Case struct {
+		name     string
+		payload  *RawPayload
+		expected string
+	}
+
+	cases := []Case{}
+
+	for _, c := range excludeEndTagCases {
+		cases = append(cases, Case{
+			name:     c.name,
+			payload:  NewRawPayload(c.nbt.tagType, c.raw.payload),
+			expected: c.json.payload.typeDefault,
+		})
+	}
+
+	cases = append(cases, Case{
+		name:     `negative case: truncated`,
+		payload:  NewRawPayload(TagTypeString, []byte{0x00, 0x05, 'a'}),
+		expected: `"<invalid String>"`,
+	})
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := string(tt.payload.AppendJSON(nil, &JsonOptions{Space: " "}, 0))
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -21,7 +21,6 @@
 package nbt
 
 import (
-	"encoding/binary"
 	"fmt"
 	"io"
 	"strconv"
@@ -37,59 +36,60 @@ func NewShortPayload(value int16) *ShortPayload {
 }
 
 func (p ShortPayload) String() string {
-	return p.stringify(" ", "", 0)
+	return string(p.AppendSNBT(nil, &StringifyOptions{Space: " "}, 0))
 }
 
 func (p *ShortPayload) TypeId() TagType {
 	return TagTypeShort
 }
 
-func (p *ShortPayload) encode(w io.Writer) error {
-	if err := binary.Write(w, binary.BigEndian, p); err != nil {
-		err = &NbtError{Op: "encode", Err: err}
-		logger.Println("failed to encode", "func", getFuncName(), "payload", p, "error", err)
+func (p *ShortPayload) EncodeNBT(w io.Writer, opts *EncodeOptions) error {
+	if err := writeInt16(w, int16(*p)); err != nil {
+		logError(opts.logger(), "failed to encode", "payload", p, "error", err)
 		return err
 	}
 
 	return nil
 }
 
-func (p *ShortPayload) decode(r io.Reader) error {
-	payload := new(ShortPayload)
-	if err := binary.Read(r, binary.BigEndian, payload); err != nil {
-		err = &NbtError{Op: "decode", Err: err}
-		logger.Println("failed to decode", "func", getFuncName(), "payload", p, "error", err)
+func (p *ShortPayload) AppendNBT(dst []byte, opts *EncodeOptions) ([]byte, error) {
+	return appendInt16(dst, int16(*p)), nil
+}
+
+func (p *ShortPayload) EncodedSize(opts *EncodeOptions) int {
+	return 2
+}
+
+func (p *ShortPayload) DecodeNBT(r io.Reader, opts *DecodeOptions) error {
+	br := asByteReader(r, opts.logger())
+
+	v, err := readInt16(br)
+	if err != nil {
+		logError(opts.logger(), "failed to decode", "payload", p, "error", err)
 		return err
 	}
 
-	*p = *payload
+	*p = ShortPayload(v)
 
 	return nil
 }
 
-func (p *ShortPayload) stringify(space string, indent string, depth int) string {
-	return fmt.Sprintf("%ds", *p)
+func (p *ShortPayload) AppendSNBT(dst []byte, opts *StringifyOptions, depth int) []byte {
+	return append(strconv.AppendInt(dst, int64(*p), 10), 's')
 }
 
 func (p *ShortPayload) parse(parser *snbt.Parser) error {
 	b, err := parser.Slice(parser.PrevToken().Index()+1, parser.CurrToken().Index())
 	if err != nil {
 		err = &NbtError{Op: "parse", Err: err}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
-		return err
-	}
-
-	g := shortPattern.FindSubmatch(b)
-	if len(g) < 2 {
-		err = &NbtError{Op: "parse", Err: ErrInvalidSnbtFormat}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+		logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 		return err
 	}
 
-	i, err := strconv.ParseInt(string(g[1]), 10, 16)
+	i, err := parseInteger(b, TagTypeShort)
 	if err != nil {
 		err = &NbtError{Op: "parse", Err: err}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+		logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 		return err
 	}
 
@@ -98,6 +98,6 @@ func (p *ShortPayload) parse(parser *snbt.Parser) error {
 	return nil
 }
 
-func (p *ShortPayload) json(space string, indent string, depth int) string {
-	return fmt.Sprintf("%d", *p)
+func (p *ShortPayload) AppendJSON(dst []byte, opts *JsonOptions, depth int) []byte {
+	return append(dst, fmt.Sprintf("%d", *p)...)
 }
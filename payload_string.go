@@ -21,11 +21,8 @@
 package nbt
 
 import (
-	"encoding/binary"
-	"fmt"
 	"io"
 	"strconv"
-	"strings"
 
 	"github.com/Aton-Kish/gonbt/pointer"
 	"github.com/Aton-Kish/gonbt/snbt"
@@ -38,83 +35,64 @@ func NewStringPayload(value string) *StringPayload {
 }
 
 func (p StringPayload) String() string {
-	return p.stringify(" ", "", 0)
+	return string(p.AppendSNBT(nil, &StringifyOptions{Space: " "}, 0))
 }
 
 func (p *StringPayload) TypeId() TagType {
 	return TagTypeString
 }
 
-func (p *StringPayload) encode(w io.Writer) error {
-	l := uint16(len(*p))
-	if err := binary.Write(w, binary.BigEndian, &l); err != nil {
-		err = &NbtError{Op: "encode", Err: err}
-		logger.Println("failed to encode", "func", getFuncName(), "payload", p, "error", err)
-		return err
-	}
-
-	b := []byte(*p)
-	if err := binary.Write(w, binary.BigEndian, b); err != nil {
-		err = &NbtError{Op: "encode", Err: err}
-		logger.Println("failed to encode", "func", getFuncName(), "payload", p, "error", err)
+func (p *StringPayload) EncodeNBT(w io.Writer, opts *EncodeOptions) error {
+	if err := writeString(w, string(*p)); err != nil {
+		logError(opts.logger(), "failed to encode", "payload", p, "error", err)
 		return err
 	}
 
 	return nil
 }
 
-func (p *StringPayload) decode(r io.Reader) error {
-	var l uint16
-	if err := binary.Read(r, binary.BigEndian, &l); err != nil {
-		err = &NbtError{Op: "decode", Err: err}
-		logger.Println("failed to decode", "func", getFuncName(), "payload", p, "error", err)
-		return err
-	}
+func (p *StringPayload) AppendNBT(dst []byte, opts *EncodeOptions) ([]byte, error) {
+	return appendString(dst, string(*p)), nil
+}
+
+func (p *StringPayload) EncodedSize(opts *EncodeOptions) int {
+	return stringSize(string(*p))
+}
 
-	b := make([]byte, l)
-	if err := binary.Read(r, binary.BigEndian, b); err != nil {
-		err = &NbtError{Op: "decode", Err: err}
-		logger.Println("failed to decode", "func", getFuncName(), "payload", p, "error", err)
+func (p *StringPayload) DecodeNBT(r io.Reader, opts *DecodeOptions) error {
+	br := asByteReader(r, opts.logger())
+
+	s, err := readString(br)
+	if err != nil {
+		logError(opts.logger(), "failed to decode", "payload", p, "error", err)
 		return err
 	}
-	*p = StringPayload(b)
+
+	*p = StringPayload(s)
 
 	return nil
 }
 
-func (p *StringPayload) stringify(space string, indent string, depth int) string {
-	s := string(*p)
-	qs := strconv.Quote(s)
-	if strings.Contains(s, "\"") && !strings.Contains(s, "'") {
-		qs = fmt.Sprintf("'%s'", qs[1:len(qs)-1])
-		qs = strings.ReplaceAll(qs, "\\\"", "\"")
-		return qs
+func (p *StringPayload) AppendSNBT(dst []byte, opts *StringifyOptions, depth int) []byte {
+	if opts == nil {
+		opts = new(StringifyOptions)
 	}
 
-	return qs
+	return opts.appendQuote(dst, string(*p))
 }
 
 func (p *StringPayload) parse(parser *snbt.Parser) error {
 	b, err := parser.Slice(parser.PrevToken().Index()+1, parser.CurrToken().Index())
 	if err != nil {
 		err = &NbtError{Op: "parse", Err: err}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+		logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 		return err
 	}
 
-	qs := string(b)
-
-	if strings.HasPrefix(qs, "'") {
-		s := qs[1 : len(qs)-1]
-		s = strings.ReplaceAll(s, "\\'", "'")
-		s = strings.ReplaceAll(s, "\"", "\\\"")
-		qs = fmt.Sprintf("\"%s\"", s)
-	}
-
-	s, err := strconv.Unquote(qs)
+	s, err := unquote(string(b))
 	if err != nil {
-		err = &NbtError{Op: "parse", Err: err}
-		logger.Println("failed to parse", "func", getFuncName(), "payload", p, "error", err)
+		err = &NbtError{Op: "parse", Err: expected("%s, found %q", TagTypeString, b)}
+		logError(parser.Logger(), "failed to parse", "payload", p, "error", err)
 		return err
 	}
 
@@ -123,7 +101,7 @@ func (p *StringPayload) parse(parser *snbt.Parser) error {
 	return nil
 }
 
-func (p *StringPayload) json(space string, indent string, depth int) string {
+func (p *StringPayload) AppendJSON(dst []byte, opts *JsonOptions, depth int) []byte {
 	s := string(*p)
-	return strconv.Quote(s)
+	return append(dst, strconv.Quote(s)...)
 }
@@ -0,0 +1,118 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"sync"
+)
+
+type arrayPool[T int8 | int32 | int64] struct {
+	mu sync.Mutex
+	// NOTE: keyed by length, arrays in a chunk mostly share a few fixed lengths
+	free map[int][][]T
+}
+
+func (p *arrayPool[T]) get(n int) []T {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if list := p.free[n]; len(list) > 0 {
+		s := list[len(list)-1]
+		p.free[n] = list[:len(list)-1]
+		return s
+	}
+
+	return make([]T, n)
+}
+
+func (p *arrayPool[T]) put(s []T) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.free == nil {
+		p.free = make(map[int][][]T)
+	}
+
+	p.free[len(s)] = append(p.free[len(s)], s)
+}
+
+type ArrayPool struct {
+	bytes arrayPool[int8]
+	ints  arrayPool[int32]
+	longs arrayPool[int64]
+}
+
+func NewArrayPool() *ArrayPool {
+	return new(ArrayPool)
+}
+
+// NOTE: gives the arrays in payload back to the pool, payload must not be used afterwards
+func (p *ArrayPool) Release(payload Payload) {
+	if p == nil {
+		return
+	}
+
+	switch payload := payload.(type) {
+	case *ByteArrayPayload:
+		p.bytes.put(*payload)
+		*payload = nil
+	case *IntArrayPayload:
+		p.ints.put(*payload)
+		*payload = nil
+	case *LongArrayPayload:
+		p.longs.put(*payload)
+		*payload = nil
+	case *ListPayload:
+		for _, elem := range payload.Values() {
+			p.Release(elem)
+		}
+	case *CompoundPayload:
+		for _, tag := range *payload {
+			if tag.TypeId() != TagTypeEnd {
+				p.Release(tag.Payload())
+			}
+		}
+	}
+}
+
+func (p *ArrayPool) int8s(n int) []int8 {
+	if p == nil {
+		return make([]int8, n)
+	}
+
+	return p.bytes.get(n)
+}
+
+func (p *ArrayPool) int32s(n int) []int32 {
+	if p == nil {
+		return make([]int32, n)
+	}
+
+	return p.ints.get(n)
+}
+
+func (p *ArrayPool) int64s(n int) []int64 {
+	if p == nil {
+		return make([]int64, n)
+	}
+
+	return p.longs.get(n)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArrayPool_Release(t *testing.T) {
+	pool := NewArrayPool()
+
+	payload := NewCompoundPayload(
+		NewByteArrayTag(NewTagName(`ByteArray`), NewByteArrayPayload(0, 1)),
+		NewListTag(NewTagName(`List`), NewListPayload(NewIntArrayPayload(2, 3))),
+		NewLongArrayTag(NewTagName(`LongArray`), NewLongArrayPayload(4, 5)),
+		NewEndTag(),
+	)
+
+	byteArray := *(*payload)[0].Payload().(*ByteArrayPayload)
+	intArray := *(*payload)[1].Payload().(*ListPayload).Values()[0].(*IntArrayPayload)
+	longArray := *(*payload)[2].Payload().(*LongArrayPayload)
+
+	pool.Release(payload)
+
+	assert.Nil(t, *(*payload)[0].Payload().(*ByteArrayPayload))
+	assert.Nil(t, *(*payload)[1].Payload().(*ListPayload).Values()[0].(*IntArrayPayload))
+	assert.Nil(t, *(*payload)[2].Payload().(*LongArrayPayload))
+
+	assert.Same(t, &byteArray[0], &pool.int8s(2)[0])
+	assert.Same(t, &intArray[0], &pool.int32s(2)[0])
+	assert.Same(t, &longArray[0], &pool.int64s(2)[0])
+
+	// NOTE: the pool is empty again
+	assert.NotSame(t, &longArray[0], &pool.int64s(2)[0])
+}
+
+func TestArrayPool_nil(t *testing.T) {
+	var pool *ArrayPool
+	assert.Equal(t, []int8{0, 0}, pool.int8s(2))
+	assert.Equal(t, []int32{0, 0}, pool.int32s(2))
+	assert.Equal(t, []int64{0, 0}, pool.int64s(2))
+
+	payload := &IntArrayPayload{1, 2}
+	assert.NotPanics(t, func() { pool.Release(payload) })
+	assert.Equal(t, &IntArrayPayload{1, 2}, payload)
+}
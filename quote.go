@@ -0,0 +1,275 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/runenames"
+)
+
+const cjkIdeographPrefix = "CJK UNIFIED IDEOGRAPH-"
+
+// NOTE: runes indexed by the first word of their names, the names themselves are not kept
+var (
+	runesByPrefix     map[string][]rune
+	runesByPrefixOnce sync.Once
+)
+
+// NOTE: only these categories have names that are not algorithmic
+var namedRanges = []*unicode.RangeTable{unicode.L, unicode.M, unicode.N, unicode.P, unicode.S, unicode.Z, unicode.Cf}
+
+// NOTE: unquotes a single or double quoted SNBT string, escapes are \\, \', \", \b, \s, \t, \n, \f, \r, \xHH, \uHHHH, \UHHHHHHHH and \N{name}
+func unquote(s string) (string, error) {
+	if len(s) < 2 || (s[0] != '"' && s[0] != '\'') || s[len(s)-1] != s[0] {
+		return "", strconv.ErrSyntax
+	}
+
+	quote := s[0]
+	s = s[1 : len(s)-1]
+
+	if strings.IndexByte(s, '\\') < 0 {
+		if strings.IndexByte(s, quote) >= 0 {
+			return "", strconv.ErrSyntax
+		}
+
+		return s, nil
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(s))
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c == quote {
+			return "", strconv.ErrSyntax
+		}
+
+		if c != '\\' {
+			sb.WriteByte(c)
+			i++
+			continue
+		}
+
+		if i+1 >= len(s) {
+			return "", strconv.ErrSyntax
+		}
+
+		i += 2
+		switch e := s[i-1]; e {
+		case '\\', '\'', '"':
+			sb.WriteByte(e)
+		case 'b':
+			sb.WriteByte('\b')
+		case 's':
+			sb.WriteByte(' ')
+		case 't':
+			sb.WriteByte('\t')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'x', 'u', 'U':
+			var n int
+			switch e {
+			case 'x':
+				n = 2
+			case 'u':
+				n = 4
+			default:
+				n = 8
+			}
+
+			if i+n > len(s) {
+				return "", strconv.ErrSyntax
+			}
+
+			v, err := strconv.ParseUint(s[i:i+n], 16, 32)
+			if err != nil || !utf8.ValidRune(rune(v)) {
+				return "", strconv.ErrSyntax
+			}
+
+			sb.WriteRune(rune(v))
+			i += n
+		case 'N':
+			end := strings.IndexByte(s[i:], '}')
+			if i >= len(s) || s[i] != '{' || end < 0 {
+				return "", strconv.ErrSyntax
+			}
+
+			r, ok := lookupRune(s[i+1 : i+end])
+			if !ok {
+				return "", strconv.ErrSyntax
+			}
+
+			sb.WriteRune(r)
+			i += end + 1
+		default:
+			return "", strconv.ErrSyntax
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// NOTE: prefers double quotes unless the string contains them and no single quotes
+func appendQuote(dst []byte, s string) []byte {
+	return appendQuoteWith(dst, s, '"')
+}
+
+// NOTE: uses the preferred quote unless the string contains it and not the other one
+func appendQuoteWith(dst []byte, s string, prefer byte) []byte {
+	quote, other := prefer, byte('\'')
+	if prefer == '\'' {
+		other = '"'
+	}
+
+	if strings.IndexByte(s, quote) >= 0 && strings.IndexByte(s, other) < 0 {
+		quote = other
+	}
+
+	dst = append(dst, quote)
+
+	for i := 0; i < len(s); {
+		r, n := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && n == 1 {
+			// NOTE: bytes that are not valid UTF-8, such as modified UTF-8 NUL and surrogates, are written as they are, which unquote reads back unchanged while every escape stands for a code point
+			dst = append(dst, s[i])
+			i++
+			continue
+		}
+
+		i += n
+
+		switch r {
+		case rune(quote), '\\':
+			dst = append(dst, '\\', byte(r))
+		case '\b':
+			dst = append(dst, `\b`...)
+		case '\t':
+			dst = append(dst, `\t`...)
+		case '\n':
+			dst = append(dst, `\n`...)
+		case '\f':
+			dst = append(dst, `\f`...)
+		case '\r':
+			dst = append(dst, `\r`...)
+		default:
+			switch {
+			case unicode.IsPrint(r) || r == ' ':
+				dst = utf8.AppendRune(dst, r)
+			case r <= 0xFFFF:
+				dst = append(dst, `\u`...)
+				dst = appendHex(dst, uint32(r), 4)
+			default:
+				dst = append(dst, `\U`...)
+				dst = appendHex(dst, uint32(r), 8)
+			}
+		}
+	}
+
+	return append(dst, quote)
+}
+
+// NOTE: before 1.21.5 only double quotes are safe and only \\ and \" are escapes
+func (o *StringifyOptions) appendQuote(dst []byte, s string) []byte {
+	if o.Dialect == DialectDefault || o.Dialect >= Dialect1215 {
+		if o.QuoteStyle == QuotePreferSingle {
+			return appendQuoteWith(dst, s, '\'')
+		}
+
+		return appendQuote(dst, s)
+	}
+
+	// NOTE: copied byte by byte, so that bytes that are not valid UTF-8 are kept
+	dst = append(dst, '"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			dst = append(dst, '\\')
+		}
+
+		dst = append(dst, s[i])
+	}
+
+	return append(dst, '"')
+}
+
+func appendHex(dst []byte, v uint32, n int) []byte {
+	const digits = "0123456789abcdef"
+	for i := n - 1; i >= 0; i-- {
+		dst = append(dst, digits[v>>(4*i)&0xF])
+	}
+
+	return dst
+}
+
+// NOTE: names are matched case insensitively as in Java's Character.codePointOf
+func lookupRune(name string) (rune, bool) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+
+	if strings.HasPrefix(name, cjkIdeographPrefix) {
+		v, err := strconv.ParseUint(name[len(cjkIdeographPrefix):], 16, 32)
+		if err != nil || runenames.Name(rune(v)) != "<CJK Ideograph>" {
+			return 0, false
+		}
+
+		return rune(v), true
+	}
+
+	runesByPrefixOnce.Do(func() {
+		runesByPrefix = make(map[string][]rune)
+		for _, t := range namedRanges {
+			for _, rg := range t.R16 {
+				for r := rune(rg.Lo); r <= rune(rg.Hi); r += rune(rg.Stride) {
+					indexRune(r)
+				}
+			}
+
+			for _, rg := range t.R32 {
+				for r := rune(rg.Lo); r <= rune(rg.Hi); r += rune(rg.Stride) {
+					indexRune(r)
+				}
+			}
+		}
+	})
+
+	prefix, _, _ := strings.Cut(name, " ")
+	for _, r := range runesByPrefix[prefix] {
+		if runenames.Name(r) == name {
+			return r, true
+		}
+	}
+
+	return 0, false
+}
+
+func indexRune(r rune) {
+	if n := runenames.Name(r); n != "" && n[0] != '<' {
+		prefix, _, _ := strings.Cut(n, " ")
+		runesByPrefix[prefix] = append(runesByPrefix[prefix], r)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_escape(t *testing.T) {
+	cases := []struct {
+		name     string
+		snbt     string
+		expected string
+	}{
+		{name: `positive case: vanilla`, snbt: `{s: "a\"b\\c\'d"}`, expected: "a\"b\\c'd"},
+		{name: `positive case: single quoted`, snbt: `{s: 'it\'s "x"'}`, expected: `it's "x"`},
+		{name: `positive case: control`, snbt: `{s: "\b\s\t\n\f\r"}`, expected: "\b \t\n\f\r"},
+		{name: `positive case: \x`, snbt: `{s: "\x41\xe9"}`, expected: "Aé"},
+		{name: `positive case: \u`, snbt: `{s: "\u00e9\u2603"}`, expected: "é☃"},
+		{name: `positive case: \U`, snbt: `{s: "\U0001F600"}`, expected: "😀"},
+		{name: `positive case: \N`, snbt: `{s: "\N{Latin Small Letter A}\N{SNOWMAN}"}`, expected: "a☃"},
+		{name: `positive case: \N ideograph`, snbt: `{s: "\N{CJK UNIFIED IDEOGRAPH-4E2D}"}`, expected: "中"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			expected := NewCompoundTag(NewTagName(``), NewCompoundPayload(
+				NewStringTag(NewTagName(`s`), NewStringPayload(tt.expected)),
+				NewEndTag(),
+			))
+
+			actual, err := Parse(tt.snbt)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestParse_escapeEncoded(t *testing.T) {
+	tag, err := Parse(`{a: "\xe9"}`)
+	assert.NoError(t, err)
+
+	actual, err := AppendEncode(nil, (*tag.Payload().(*CompoundPayload))[0])
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x08, 0x00, 0x01, 'a', 0x00, 0x02, 0xC3, 0xA9}, actual)
+}
+
+func TestParse_escapeError(t *testing.T) {
+	cases := []struct {
+		name string
+		snbt string
+	}{
+		{name: `negative case: unknown escape`, snbt: `{s: "\q"}`},
+		{name: `negative case: short \x`, snbt: `{s: "\x4"}`},
+		{name: `negative case: surrogate \u`, snbt: `{s: "\ud800"}`},
+		{name: `negative case: out of range \U`, snbt: `{s: "\U00110000"}`},
+		{name: `negative case: unknown name`, snbt: `{s: "\N{NO SUCH CHARACTER}"}`},
+		{name: `negative case: unterminated name`, snbt: `{s: "\N{SNOWMAN"}`},
+		{name: `negative case: quoted key`, snbt: `{"\q": 1b}`},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.snbt)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestAppendQuote(t *testing.T) {
+	cases := []struct {
+		name     string
+		s        string
+		expected string
+	}{
+		{name: `positive case: plain`, s: `Hello World`, expected: `"Hello World"`},
+		{name: `positive case: double quote`, s: `say "hi"`, expected: `'say "hi"'`},
+		{name: `positive case: both quotes`, s: `it's "x"`, expected: `"it's \"x\""`},
+		{name: `positive case: control`, s: "a\tb\nc", expected: `"a\tb\nc"`},
+		{name: `positive case: non printable`, s: "\x00\u200b\U000E0001", expected: `"\u0000\u200b\U000e0001"`},
+		{name: `positive case: unicode`, s: "é☃😀", expected: `"é☃😀"`},
+		{name: `positive case: modified UTF-8`, s: "\xc0\x80\xed\xa0\xbd\xed\xb8\x80", expected: "\"\xc0\x80\xed\xa0\xbd\xed\xb8\x80\""},
+		{name: `positive case: invalid byte`, s: "a\xffé", expected: "\"a\xffé\""},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := string(appendQuote(nil, tt.s))
+			assert.Equal(t, tt.expected, actual)
+
+			s, err := unquote(actual)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.s, s)
+		})
+	}
+}
+
+func TestStringify_invalidUTF8(t *testing.T) {
+	// NOTE: modified UTF-8 NUL followed by U+1F600 as a surrogate pair
+	s := "\xc0\x80\xed\xa0\xbd\xed\xb8\x80"
+	tag := NewCompoundTag(NewTagName(``), NewCompoundPayload(
+		NewStringTag(NewTagName(s), NewStringPayload(s)),
+		NewEndTag(),
+	))
+
+	cases := []struct {
+		name string
+		opts *StringifyOptions
+	}{
+		{name: `positive case: default`, opts: &StringifyOptions{}},
+		{name: `positive case: single quotes`, opts: &StringifyOptions{QuoteStyle: QuotePreferSingle}},
+		{name: `positive case: before 1.21.5`, opts: &StringifyOptions{Dialect: Dialect113}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := Parse(StringifyWithOptions(tag, tt.opts))
+			assert.NoError(t, err)
+			assert.Equal(t, tag, actual)
+		})
+	}
+}
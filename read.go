@@ -0,0 +1,395 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"encoding/binary"
+	"io"
+	"log/slog"
+	"math"
+	"slices"
+	"unsafe"
+)
+
+const (
+	bufferSize  = 64 * 1024
+	scratchSize = 4096
+	// NOTE: elements allocated ahead of the input on streams, whose declared lengths cannot be checked up front
+	maxPrealloc = 1024
+)
+
+type discarder interface {
+	Discard(n int) (int, error)
+}
+
+// NOTE: byteReader is passed down as the io.Reader of nested DecodeNBT calls, so one decode shares a single scratch buffer
+type byteReader struct {
+	r io.Reader
+	d discarder
+
+	// NOTE: when src is set, values are read from src[off:] without copying
+	src          []byte
+	off          int
+	shareStrings bool
+
+	small   [8]byte
+	scratch []byte
+
+	logger *slog.Logger
+}
+
+// NOTE: an existing byteReader is returned as is and keeps its logger
+func asByteReader(r io.Reader, logger *slog.Logger) *byteReader {
+	if br, ok := r.(*byteReader); ok {
+		return br
+	}
+
+	br := &byteReader{r: r, logger: logger}
+	br.d, _ = r.(discarder)
+
+	return br
+}
+
+func newSliceReader(b []byte, shareStrings bool, logger *slog.Logger) *byteReader {
+	return &byteReader{src: b, shareStrings: shareStrings, logger: logger}
+}
+
+func (br *byteReader) Read(p []byte) (int, error) {
+	if br.src == nil {
+		return br.r.Read(p)
+	}
+
+	if br.off >= len(br.src) && len(p) > 0 {
+		return 0, io.EOF
+	}
+
+	n := copy(p, br.src[br.off:])
+	br.off += n
+
+	return n, nil
+}
+
+func (br *byteReader) buffer(n int) []byte {
+	if cap(br.scratch) < n {
+		size := scratchSize
+		if size < n {
+			size = n
+		}
+
+		br.scratch = make([]byte, size)
+	}
+
+	return br.scratch[:n]
+}
+
+func (br *byteReader) readFull(b []byte) error {
+	if _, err := io.ReadFull(br.r, b); err != nil {
+		err = &NbtError{Op: "decode", Err: err}
+		logError(br.logger, "failed to decode", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// NOTE: the returned bytes are only valid until the next read
+func (br *byteReader) next(n int) ([]byte, error) {
+	if br.src != nil {
+		if err := br.advance(int64(n)); err != nil {
+			return nil, err
+		}
+
+		return br.src[br.off-n : br.off], nil
+	}
+
+	var b []byte
+	if n <= len(br.small) {
+		b = br.small[:n]
+	} else {
+		b = br.buffer(n)
+	}
+
+	if err := br.readFull(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func (br *byteReader) advance(n int64) error {
+	if rest := int64(len(br.src) - br.off); rest < n {
+		err := io.ErrUnexpectedEOF
+		if rest == 0 {
+			err = io.EOF
+		}
+
+		br.off = len(br.src)
+
+		e := &NbtError{Op: "decode", Err: err}
+		logError(br.logger, "failed to decode", "error", e)
+		return e
+	}
+
+	br.off += int(n)
+
+	return nil
+}
+
+// NOTE: in-memory input must hold n values of size bytes before they are allocated, streams are read as is
+func (br *byteReader) ensure(n int, size int) error {
+	if br.src == nil || int64(n)*int64(size) <= int64(len(br.src)-br.off) {
+		return nil
+	}
+
+	br.off = len(br.src)
+
+	err := &NbtError{Op: "decode", Err: io.ErrUnexpectedEOF}
+	logError(br.logger, "failed to decode", "length", n, "error", err)
+	return err
+}
+
+// NOTE: in-memory input is checked by ensure, streams grow as elements are read
+func (br *byteReader) prealloc(n int) int {
+	if br.src == nil {
+		return min(n, maxPrealloc)
+	}
+
+	return n
+}
+
+// NOTE: streams are read in chunks of growing size, so that a huge declared length fails with io.ErrUnexpectedEOF instead of exhausting memory
+func readArray[T int8 | int32 | int64](br *byteReader, l int, alloc func(n int) []T, read func(br *byteReader, dst []T) error) ([]T, error) {
+	if br.prealloc(l) == l {
+		dst := alloc(l)
+		return dst, read(br, dst)
+	}
+
+	dst := alloc(maxPrealloc)
+	if err := read(br, dst); err != nil {
+		return nil, err
+	}
+
+	for len(dst) < l {
+		n := min(l-len(dst), len(dst))
+		dst = slices.Grow(dst, n)[:len(dst)+n]
+		if err := read(br, dst[len(dst)-n:]); err != nil {
+			return nil, err
+		}
+	}
+
+	return dst, nil
+}
+
+func (br *byteReader) skip(n int64) error {
+	if br.src != nil {
+		return br.advance(n)
+	}
+
+	if br.d != nil && int64(int(n)) == n {
+		if _, err := br.d.Discard(int(n)); err != nil {
+			err = &NbtError{Op: "decode", Err: err}
+			logError(br.logger, "failed to decode", "error", err)
+			return err
+		}
+
+		return nil
+	}
+
+	for n > 0 {
+		size := int64(scratchSize)
+		if size > n {
+			size = n
+		}
+
+		if err := br.readFull(br.buffer(int(size))); err != nil {
+			return err
+		}
+
+		n -= size
+	}
+
+	return nil
+}
+
+func readTagType(br *byteReader) (TagType, error) {
+	v, err := readInt8(br)
+	return TagType(v), err
+}
+
+func readInt8(br *byteReader) (int8, error) {
+	b, err := br.next(1)
+	if err != nil {
+		return 0, err
+	}
+
+	return int8(b[0]), nil
+}
+
+func readUint16(br *byteReader) (uint16, error) {
+	b, err := br.next(2)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint16(b), nil
+}
+
+func readInt16(br *byteReader) (int16, error) {
+	v, err := readUint16(br)
+	return int16(v), err
+}
+
+func readInt32(br *byteReader) (int32, error) {
+	b, err := br.next(4)
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(binary.BigEndian.Uint32(b)), nil
+}
+
+func readInt64(br *byteReader) (int64, error) {
+	b, err := br.next(8)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(binary.BigEndian.Uint64(b)), nil
+}
+
+func readFloat32(br *byteReader) (float32, error) {
+	v, err := readInt32(br)
+	return math.Float32frombits(uint32(v)), err
+}
+
+func readFloat64(br *byteReader) (float64, error) {
+	v, err := readInt64(br)
+	return math.Float64frombits(uint64(v)), err
+}
+
+func readString(br *byteReader) (string, error) {
+	l, err := readUint16(br)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := br.next(int(l))
+	if err != nil {
+		return "", err
+	}
+
+	if br.shareStrings && br.src != nil && len(b) > 0 {
+		return *(*string)(unsafe.Pointer(&b)), nil
+	}
+
+	return string(b), nil
+}
+
+// NOTE: lengths of lists and arrays are signed 32-bit integers, negative values are rejected
+func readLength(br *byteReader) (int, error) {
+	l, err := readInt32(br)
+	if err != nil {
+		return 0, err
+	}
+
+	if l < 0 {
+		err := &NbtError{Op: "decode", Err: ErrDecode}
+		logError(br.logger, "failed to decode", "length", l, "error", err)
+		return 0, err
+	}
+
+	return int(l), nil
+}
+
+func readInt8s(br *byteReader, dst []int8) error {
+	for len(dst) > 0 {
+		n := len(dst)
+		if br.src == nil && n > scratchSize {
+			n = scratchSize
+		}
+
+		b, err := br.next(n)
+		if err != nil {
+			return err
+		}
+
+		for i, v := range b {
+			dst[i] = int8(v)
+		}
+
+		dst = dst[n:]
+	}
+
+	return nil
+}
+
+func readInt32s(br *byteReader, dst []int32) error {
+	for len(dst) > 0 {
+		n := len(dst)
+		if br.src == nil && n > scratchSize/4 {
+			n = scratchSize / 4
+		}
+
+		b, err := br.next(n * 4)
+		if err != nil {
+			return err
+		}
+
+		for i := range dst[:n] {
+			dst[i] = int32(binary.BigEndian.Uint32(b[i*4:]))
+		}
+
+		dst = dst[n:]
+	}
+
+	return nil
+}
+
+func readInt64s(br *byteReader, dst []int64) error {
+	for len(dst) > 0 {
+		n := len(dst)
+		if br.src == nil && n > scratchSize/8 {
+			n = scratchSize / 8
+		}
+
+		b, err := br.next(n * 8)
+		if err != nil {
+			return err
+		}
+
+		for i := range dst[:n] {
+			dst[i] = int64(binary.BigEndian.Uint64(b[i*8:]))
+		}
+
+		dst = dst[n:]
+	}
+
+	return nil
+}
+
+// NOTE: running out of input inside a tag is always unexpected
+func unexpectedEOF(err error) error {
+	if e, ok := err.(*NbtError); ok && e.Err == io.EOF {
+		return &NbtError{Op: e.Op, Err: io.ErrUnexpectedEOF}
+	}
+
+	return err
+}
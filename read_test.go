@@ -0,0 +1,118 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadInt64s(t *testing.T) {
+	cases := []struct {
+		name        string
+		size        int
+		expectedErr error
+	}{
+		{
+			name:        `positive case: empty`,
+			size:        0,
+			expectedErr: nil,
+		},
+		{
+			name:        `positive case: within scratch buffer`,
+			size:        scratchSize / 8,
+			expectedErr: nil,
+		},
+		{
+			name:        `positive case: across scratch buffers`,
+			size:        scratchSize/8*2 + 1,
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			expected := make(LongArrayPayload, tt.size)
+			for i := range expected {
+				expected[i] = int64(i) * -0x0123456789
+			}
+
+			buf := new(bytes.Buffer)
+			err := expected.EncodeNBT(buf, new(EncodeOptions))
+			assert.NoError(t, err)
+
+			br := asByteReader(buf, nil)
+			l, err := readLength(br)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.size, l)
+
+			actual := make([]int64, l)
+			err = readInt64s(br, actual)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, []int64(expected), actual)
+		})
+	}
+}
+
+func TestReadString(t *testing.T) {
+	cases := []struct {
+		name        string
+		raw         []byte
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:        `positive case: empty`,
+			raw:         []byte{0x00, 0x00},
+			expected:    ``,
+			expectedErr: nil,
+		},
+		{
+			name:        `positive case: ascii`,
+			raw:         []byte{0x00, 0x05, 0x48, 0x65, 0x6C, 0x6C, 0x6F},
+			expected:    `Hello`,
+			expectedErr: nil,
+		},
+		{
+			name:        `negative case: empty input`,
+			raw:         []byte{},
+			expected:    ``,
+			expectedErr: &NbtError{Op: "decode", Err: io.EOF},
+		},
+		{
+			name:        `negative case: truncated`,
+			raw:         []byte{0x00, 0x05, 0x48, 0x65},
+			expected:    ``,
+			expectedErr: &NbtError{Op: "decode", Err: io.ErrUnexpectedEOF},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := readString(asByteReader(bytes.NewBuffer(tt.raw), nil))
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"bufio"
+	"io"
+)
+
+const defaultChunkSize = 4096
+
+type EventKind byte
+
+const (
+	EventBeginCompound EventKind = iota + 1
+	EventBeginList
+	EventBeginArray
+	EventScalar
+	EventArrayChunk
+	EventEnd
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventBeginCompound:
+		return "BeginCompound"
+	case EventBeginList:
+		return "BeginList"
+	case EventBeginArray:
+		return "BeginArray"
+	case EventScalar:
+		return "Scalar"
+	case EventArrayChunk:
+		return "ArrayChunk"
+	case EventEnd:
+		return "End"
+	default:
+		return ""
+	}
+}
+
+type Event struct {
+	Kind EventKind
+	// NOTE: empty for list elements and End events
+	Name TagName
+	// NOTE: type of the compound, list, array or scalar the event belongs to
+	Type TagType
+	// NOTE: element type of BeginList events
+	ElemType TagType
+	// NOTE: number of elements of BeginList and BeginArray events
+	Len int
+	// NOTE: decoded payload of Scalar events, or the elements of ArrayChunk events which are only valid until the next call to Next
+	Value Payload
+}
+
+type readerFrame struct {
+	typ       TagType
+	elemType  TagType
+	remaining int
+}
+
+type Reader struct {
+	br        *byteReader
+	opts      *DecodeOptions
+	chunkSize int
+	stack     []readerFrame
+
+	byteChunk ByteArrayPayload
+	intChunk  IntArrayPayload
+	longChunk LongArrayPayload
+}
+
+// NOTE: r is buffered, so the Reader may read ahead of the last event
+func NewReader(r io.Reader) *Reader {
+	return NewReaderSize(r, defaultChunkSize)
+}
+
+func NewReaderSize(r io.Reader, chunkSize int) *Reader {
+	return newReader(r, chunkSize, new(DecodeOptions))
+}
+
+func NewReaderWithOptions(r io.Reader, opts *DecodeOptions) *Reader {
+	return newReader(r, defaultChunkSize, opts)
+}
+
+func newReader(r io.Reader, chunkSize int, opts *DecodeOptions) *Reader {
+	if chunkSize <= 0 {
+		chunkSize = defaultChunkSize
+	}
+
+	return &Reader{
+		br:        asByteReader(bufio.NewReaderSize(r, bufferSize), opts.logger()),
+		opts:      opts,
+		chunkSize: chunkSize,
+	}
+}
+
+func (r *Reader) Depth() int {
+	return len(r.stack)
+}
+
+// NOTE: returns io.EOF when the stream ends cleanly before the next root tag
+func (r *Reader) Next() (Event, error) {
+	if len(r.stack) == 0 {
+		return r.nextRoot()
+	}
+
+	frame := &r.stack[len(r.stack)-1]
+	switch frame.typ {
+	case TagTypeCompound:
+		typ, err := readTagType(r.br)
+		if err != nil {
+			logError(r.opts.logger(), "failed to read", "error", err)
+			return Event{}, unexpectedEOF(err)
+		}
+
+		if typ == TagTypeEnd {
+			return r.end(), nil
+		}
+
+		name, err := readString(r.br)
+		if err != nil {
+			logError(r.opts.logger(), "failed to read", "error", err)
+			return Event{}, unexpectedEOF(err)
+		}
+
+		return r.value(TagName(name), typ)
+	case TagTypeList:
+		if frame.remaining == 0 {
+			return r.end(), nil
+		}
+
+		frame.remaining--
+
+		return r.value("", frame.elemType)
+	default:
+		if frame.remaining == 0 {
+			return r.end(), nil
+		}
+
+		return r.chunk(frame)
+	}
+}
+
+// NOTE: skips the rest of the innermost compound, list or array as if its End event had been read
+func (r *Reader) Skip() error {
+	if len(r.stack) == 0 {
+		return nil
+	}
+
+	frame := r.stack[len(r.stack)-1]
+	r.stack = r.stack[:len(r.stack)-1]
+
+	var err error
+	switch frame.typ {
+	case TagTypeCompound:
+		err = skipCompound(r.br, r.opts)
+	case TagTypeList:
+		for i := 0; i < frame.remaining && err == nil; i++ {
+			if err = r.opts.checkContext(i); err == nil {
+				err = skipPayload(r.br, frame.elemType, r.opts)
+			}
+		}
+	case TagTypeByteArray:
+		err = skipBytes(r.br, int64(frame.remaining))
+	case TagTypeIntArray:
+		err = skipBytes(r.br, int64(frame.remaining)*4)
+	case TagTypeLongArray:
+		err = skipBytes(r.br, int64(frame.remaining)*8)
+	}
+
+	if err != nil {
+		logError(r.opts.logger(), "failed to skip", "error", err)
+		return unexpectedEOF(err)
+	}
+
+	return nil
+}
+
+func (r *Reader) nextRoot() (Event, error) {
+	typ, err := readTagType(r.br)
+	if err != nil {
+		// NOTE: a clean end of stream between root tags is not an error
+		if e, ok := err.(*NbtError); ok && e.Err == io.EOF {
+			return Event{}, io.EOF
+		}
+
+		logError(r.opts.logger(), "failed to read", "error", err)
+		return Event{}, err
+	}
+
+	if typ == TagTypeEnd {
+		return Event{Kind: EventEnd, Type: TagTypeEnd}, nil
+	}
+
+	name, err := readString(r.br)
+	if err != nil {
+		logError(r.opts.logger(), "failed to read", "error", err)
+		return Event{}, unexpectedEOF(err)
+	}
+
+	return r.value(TagName(name), typ)
+}
+
+func (r *Reader) value(name TagName, typ TagType) (Event, error) {
+	switch typ {
+	case TagTypeCompound:
+		r.stack = append(r.stack, readerFrame{typ: typ})
+		return Event{Kind: EventBeginCompound, Name: name, Type: typ}, nil
+	case TagTypeList:
+		elemType, err := readTagType(r.br)
+		if err != nil {
+			logError(r.opts.logger(), "failed to read", "error", err)
+			return Event{}, unexpectedEOF(err)
+		}
+
+		l, err := readLength(r.br)
+		if err != nil {
+			logError(r.opts.logger(), "failed to read", "error", err)
+			return Event{}, unexpectedEOF(err)
+		}
+
+		r.stack = append(r.stack, readerFrame{typ: typ, elemType: elemType, remaining: l})
+		return Event{Kind: EventBeginList, Name: name, Type: typ, ElemType: elemType, Len: l}, nil
+	case TagTypeByteArray, TagTypeIntArray, TagTypeLongArray:
+		l, err := readLength(r.br)
+		if err != nil {
+			logError(r.opts.logger(), "failed to read", "error", err)
+			return Event{}, unexpectedEOF(err)
+		}
+
+		r.stack = append(r.stack, readerFrame{typ: typ, remaining: l})
+		return Event{Kind: EventBeginArray, Name: name, Type: typ, Len: l}, nil
+	default:
+		payload, err := NewPayload(typ)
+		if err != nil {
+			err = &NbtError{Op: "decode", Err: err}
+			logError(r.opts.logger(), "failed to read", "type", typ, "error", err)
+			return Event{}, err
+		}
+
+		if err := payload.DecodeNBT(r.br, r.opts); err != nil {
+			logError(r.opts.logger(), "failed to read", "error", err)
+			return Event{}, unexpectedEOF(err)
+		}
+
+		return Event{Kind: EventScalar, Name: name, Type: typ, Value: payload}, nil
+	}
+}
+
+func (r *Reader) chunk(frame *readerFrame) (Event, error) {
+	n := frame.remaining
+	if n > r.chunkSize {
+		n = r.chunkSize
+	}
+
+	var value Payload
+	var err error
+	switch frame.typ {
+	case TagTypeByteArray:
+		if cap(r.byteChunk) < n {
+			r.byteChunk = make(ByteArrayPayload, r.chunkSize)
+		}
+
+		r.byteChunk = r.byteChunk[:n]
+		err = readInt8s(r.br, r.byteChunk)
+		value = &r.byteChunk
+	case TagTypeIntArray:
+		if cap(r.intChunk) < n {
+			r.intChunk = make(IntArrayPayload, r.chunkSize)
+		}
+
+		r.intChunk = r.intChunk[:n]
+		err = readInt32s(r.br, r.intChunk)
+		value = &r.intChunk
+	case TagTypeLongArray:
+		if cap(r.longChunk) < n {
+			r.longChunk = make(LongArrayPayload, r.chunkSize)
+		}
+
+		r.longChunk = r.longChunk[:n]
+		err = readInt64s(r.br, r.longChunk)
+		value = &r.longChunk
+	}
+
+	if err != nil {
+		logError(r.opts.logger(), "failed to read", "error", err)
+		return Event{}, unexpectedEOF(err)
+	}
+
+	frame.remaining -= n
+
+	return Event{Kind: EventArrayChunk, Type: frame.typ, Len: n, Value: value}, nil
+}
+
+func (r *Reader) end() Event {
+	frame := r.stack[len(r.stack)-1]
+	r.stack = r.stack[:len(r.stack)-1]
+
+	return Event{Kind: EventEnd, Type: frame.typ}
+}
@@ -0,0 +1,253 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/Aton-Kish/gonbt/pointer"
+	"github.com/Aton-Kish/gonbt/slices"
+	"github.com/stretchr/testify/assert"
+)
+
+func readTestPayload(t *testing.T, r *Reader, ev Event) Payload {
+	switch ev.Kind {
+	case EventBeginCompound:
+		payload := CompoundPayload{}
+		for {
+			child, err := r.Next()
+			assert.NoError(t, err)
+
+			if child.Kind == EventEnd {
+				payload = append(payload, NewEndTag())
+				return &payload
+			}
+
+			tag, err := newTagWithPayload(pointer.Pointer(child.Name), readTestPayload(t, r, child))
+			assert.NoError(t, err)
+
+			payload = append(payload, tag)
+		}
+	case EventBeginList:
+		payload := NewEmptyListPayload(ev.ElemType)
+		for {
+			child, err := r.Next()
+			assert.NoError(t, err)
+
+			if child.Kind == EventEnd {
+				return payload
+			}
+
+			payload.Append(readTestPayload(t, r, child))
+		}
+	case EventBeginArray:
+		var payload Payload
+		switch ev.Type {
+		case TagTypeByteArray:
+			payload = pointer.Pointer(make(ByteArrayPayload, 0, ev.Len))
+		case TagTypeIntArray:
+			payload = pointer.Pointer(make(IntArrayPayload, 0, ev.Len))
+		case TagTypeLongArray:
+			payload = pointer.Pointer(make(LongArrayPayload, 0, ev.Len))
+		}
+
+		for {
+			child, err := r.Next()
+			assert.NoError(t, err)
+
+			if child.Kind == EventEnd {
+				return payload
+			}
+
+			switch p := payload.(type) {
+			case *ByteArrayPayload:
+				*p = append(*p, *child.Value.(*ByteArrayPayload)...)
+			case *IntArrayPayload:
+				*p = append(*p, *child.Value.(*IntArrayPayload)...)
+			case *LongArrayPayload:
+				*p = append(*p, *child.Value.(*LongArrayPayload)...)
+			}
+		}
+	default:
+		return ev.Value
+	}
+}
+
+func TestReader_Next(t *testing.T) {
+	type Case struct {
+		name      string
+		raw       []byte
+		chunkSize int
+		expected  Tag
+	}
+
+	cases := []Case{}
+
+	for _, c := range excludeEndTagCases {
+		c := c
+		tag, err := newTagWithPayload(&c.nbt.tagName, c.nbt.payload)
+		assert.NoError(t, err)
+
+		for _, chunkSize := range []int{1, 3, defaultChunkSize} {
+			cases = append(cases, Case{
+				name:      c.name,
+				raw:       slices.Concat(c.raw.tagType, c.raw.tagName, c.raw.payload),
+				chunkSize: chunkSize,
+				expected:  tag,
+			})
+		}
+	}
+
+	for _, c := range nbtCases {
+		cases = append(cases, Case{
+			name:      c.name,
+			raw:       c.raw,
+			chunkSize: defaultChunkSize,
+			expected:  c.nbt,
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReaderSize(bytes.NewBuffer(tt.raw), tt.chunkSize)
+
+			ev, err := r.Next()
+			assert.NoError(t, err)
+			assert.Equal(t, *tt.expected.TagName(), ev.Name)
+			assert.Equal(t, tt.expected.TypeId(), ev.Type)
+
+			actual, err := newTagWithPayload(pointer.Pointer(ev.Name), readTestPayload(t, r, ev))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+			assert.Equal(t, 0, r.Depth())
+
+			_, err = r.Next()
+			assert.Equal(t, io.EOF, err)
+		})
+	}
+}
+
+func TestReader_Next_events(t *testing.T) {
+	r := NewReaderSize(bytes.NewBuffer(nbtCases[1].raw), 1)
+
+	expected := []Event{
+		{Kind: EventBeginCompound, Name: `Compound`, Type: TagTypeCompound},
+		{Kind: EventScalar, Name: `Short`, Type: TagTypeShort, Value: NewShortPayload(12345)},
+		{Kind: EventBeginArray, Name: `ByteArray`, Type: TagTypeByteArray, Len: 2},
+		{Kind: EventArrayChunk, Type: TagTypeByteArray, Len: 1, Value: NewByteArrayPayload(0)},
+		{Kind: EventArrayChunk, Type: TagTypeByteArray, Len: 1, Value: NewByteArrayPayload(1)},
+		{Kind: EventEnd, Type: TagTypeByteArray},
+		{Kind: EventScalar, Name: `String`, Type: TagTypeString, Value: NewStringPayload(`Hello`)},
+		{Kind: EventBeginList, Name: `List`, Type: TagTypeList, ElemType: TagTypeByte, Len: 1},
+		{Kind: EventScalar, Type: TagTypeByte, Value: NewBytePayload(123)},
+		{Kind: EventEnd, Type: TagTypeList},
+		{Kind: EventBeginCompound, Name: `Compound`, Type: TagTypeCompound},
+		{Kind: EventScalar, Name: `String`, Type: TagTypeString, Value: NewStringPayload(`World`)},
+		{Kind: EventEnd, Type: TagTypeCompound},
+		{Kind: EventEnd, Type: TagTypeCompound},
+	}
+
+	for _, e := range expected {
+		actual, err := r.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, e, actual)
+	}
+
+	_, err := r.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestReader_Skip(t *testing.T) {
+	r := NewReader(bytes.NewBuffer(nbtCases[1].raw))
+
+	kinds := []EventKind{}
+	for {
+		ev, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+
+		kinds = append(kinds, ev.Kind)
+
+		// NOTE: skip everything nested below the root compound
+		isBegin := ev.Kind == EventBeginCompound || ev.Kind == EventBeginList || ev.Kind == EventBeginArray
+		if isBegin && r.Depth() > 1 {
+			err := r.Skip()
+			assert.NoError(t, err)
+		}
+	}
+
+	expected := []EventKind{
+		EventBeginCompound,
+		EventScalar,
+		EventBeginArray,
+		EventScalar,
+		EventBeginList,
+		EventBeginCompound,
+		EventEnd,
+	}
+	assert.Equal(t, expected, kinds)
+}
+
+func TestReader_Next_error(t *testing.T) {
+	cases := []struct {
+		name        string
+		raw         []byte
+		expectedErr error
+	}{
+		{
+			name:        `negative case: empty`,
+			raw:         []byte{},
+			expectedErr: io.EOF,
+		},
+		{
+			name:        `negative case: truncated`,
+			raw:         nbtCases[1].raw[:len(nbtCases[1].raw)-1],
+			expectedErr: &NbtError{Op: "decode", Err: io.ErrUnexpectedEOF},
+		},
+		{
+			name:        `negative case: invalid tag type`,
+			raw:         []byte{0x0D, 0x00, 0x00},
+			expectedErr: &NbtError{Op: "decode", Err: &NbtError{Op: "new", Err: ErrInvalidTagType}},
+		},
+		{
+			name:        `negative case: negative length`,
+			raw:         []byte{0x0B, 0x00, 0x00, 0xFF, 0xFF, 0xFF, 0xFF},
+			expectedErr: &NbtError{Op: "decode", Err: ErrDecode},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReader(bytes.NewBuffer(tt.raw))
+
+			var err error
+			for err == nil {
+				_, err = r.Next()
+			}
+
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// NOTE: a nil selection under a key selects the whole subtree
+type selection map[string]selection
+
+func newSelection(paths ...string) selection {
+	sel := selection{}
+
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+
+		node := sel
+		keys := strings.Split(path, ".")
+		for i, key := range keys {
+			child, ok := node[key]
+			if ok && child == nil {
+				break
+			}
+
+			if i == len(keys)-1 {
+				node[key] = nil
+				break
+			}
+
+			if !ok {
+				child = selection{}
+				node[key] = child
+			}
+
+			node = child
+		}
+	}
+
+	return sel
+}
+
+func DecodeSelect(r io.Reader, paths ...string) (Tag, error) {
+	return DecodeSelectWithOptions(r, new(DecodeOptions), paths...)
+}
+
+func DecodeSelectWithOptions(r io.Reader, opts *DecodeOptions, paths ...string) (Tag, error) {
+	if opts == nil {
+		opts = new(DecodeOptions)
+	}
+
+	selOpts := *opts
+	selOpts.selection = newSelection(paths...)
+
+	tag, err := DecodeWithOptions(r, &selOpts)
+	if err != nil {
+		logError(opts.logger(), "failed to decode", "paths", paths, "error", err)
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// NOTE: returns a nil tag when the child is not selected and its payload has been skipped
+func decodeSelectedTag(r io.Reader, opts *DecodeOptions) (Tag, error) {
+	br := asByteReader(r, opts.logger())
+
+	var typ TagType
+	if err := typ.decode(br); err != nil {
+		logError(opts.logger(), "failed to decode", "error", err)
+		return nil, err
+	}
+
+	tag, err := newLazyTag(typ, opts.Lazy)
+	if err != nil {
+		err = &NbtError{Op: "decode", Err: err}
+		logError(opts.logger(), "failed to decode", "error", err)
+		return nil, err
+	}
+
+	if typ == TagTypeEnd {
+		return tag, nil
+	}
+
+	if err := tag.TagName().decode(br); err != nil {
+		logError(opts.logger(), "failed to decode", "error", err)
+		return nil, err
+	}
+
+	child, ok := opts.selection[string(*tag.TagName())]
+	if !ok {
+		if logEnabled(opts.logger(), slog.LevelDebug) {
+			logDebug(opts.logger(), "skipping unselected tag", "type", typ.String(), "name", string(*tag.TagName()))
+		}
+
+		if err := skipPayload(br, typ, opts); err != nil {
+			logError(opts.logger(), "failed to decode", "error", err)
+			return nil, err
+		}
+
+		return nil, nil
+	}
+
+	childOpts := *opts
+	childOpts.selection = child
+
+	if err := tag.Payload().DecodeNBT(br, &childOpts); err != nil {
+		logError(opts.logger(), "failed to decode", "error", err)
+		return nil, err
+	}
+
+	return tag, nil
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSelection(t *testing.T) {
+	cases := []struct {
+		name     string
+		paths    []string
+		expected selection
+	}{
+		{
+			name:     `positive case: empty`,
+			paths:    []string{},
+			expected: selection{},
+		},
+		{
+			name:     `positive case: single key`,
+			paths:    []string{`Status`},
+			expected: selection{`Status`: nil},
+		},
+		{
+			name:  `positive case: nested keys`,
+			paths: []string{`Data.Player.Pos`, `Data.Time`},
+			expected: selection{
+				`Data`: selection{
+					`Player`: selection{`Pos`: nil},
+					`Time`:   nil,
+				},
+			},
+		},
+		{
+			name:     `positive case: prefix wins`,
+			paths:    []string{`Data.Player.Pos`, `Data`, `Data.Time`},
+			expected: selection{`Data`: nil},
+		},
+		{
+			name:     `positive case: empty path`,
+			paths:    []string{``},
+			expected: selection{},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := newSelection(tt.paths...)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestDecodeSelect(t *testing.T) {
+	items := NewCompoundTag(NewTagName(``), NewCompoundPayload(
+		NewIntTag(NewTagName(`DataVersion`), NewIntPayload(3465)),
+		NewListTag(NewTagName(`Items`), NewListPayload(
+			NewCompoundPayload(
+				NewStringTag(NewTagName(`id`), NewStringPayload(`minecraft:stone`)),
+				NewByteTag(NewTagName(`Count`), NewBytePayload(64)),
+				NewEndTag(),
+			),
+			NewCompoundPayload(
+				NewStringTag(NewTagName(`id`), NewStringPayload(`minecraft:dirt`)),
+				NewByteTag(NewTagName(`Count`), NewBytePayload(1)),
+				NewEndTag(),
+			),
+		)),
+		NewEndTag(),
+	))
+
+	itemsRaw := new(bytes.Buffer)
+	err := Encode(itemsRaw, items)
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name        string
+		raw         []byte
+		paths       []string
+		expected    Tag
+		expectedErr error
+	}{
+		{
+			name:  `positive case: scalar and nested compound`,
+			raw:   nbtCases[1].raw,
+			paths: []string{`Short`, `Compound.String`},
+			expected: NewCompoundTag(NewTagName(`Compound`), NewCompoundPayload(
+				NewShortTag(NewTagName(`Short`), NewShortPayload(12345)),
+				NewCompoundTag(NewTagName(`Compound`), NewCompoundPayload(
+					NewStringTag(NewTagName(`String`), NewStringPayload(`World`)),
+					NewEndTag(),
+				)),
+				NewEndTag(),
+			)),
+			expectedErr: nil,
+		},
+		{
+			name:  `positive case: whole subtree`,
+			raw:   nbtCases[0].raw,
+			paths: []string{`Hello World`},
+			expected: NewCompoundTag(NewTagName(``), NewCompoundPayload(
+				NewCompoundTag(NewTagName(`Hello World`), NewCompoundPayload(
+					NewStringTag(NewTagName(`Name`), NewStringPayload(`Steve`)),
+					NewEndTag(),
+				)),
+				NewEndTag(),
+			)),
+			expectedErr: nil,
+		},
+		{
+			name:  `positive case: through list`,
+			raw:   itemsRaw.Bytes(),
+			paths: []string{`Items.id`},
+			expected: NewCompoundTag(NewTagName(``), NewCompoundPayload(
+				NewListTag(NewTagName(`Items`), NewListPayload(
+					NewCompoundPayload(
+						NewStringTag(NewTagName(`id`), NewStringPayload(`minecraft:stone`)),
+						NewEndTag(),
+					),
+					NewCompoundPayload(
+						NewStringTag(NewTagName(`id`), NewStringPayload(`minecraft:dirt`)),
+						NewEndTag(),
+					),
+				)),
+				NewEndTag(),
+			)),
+			expectedErr: nil,
+		},
+		{
+			name:  `positive case: no match`,
+			raw:   nbtCases[1].raw,
+			paths: []string{`Missing`},
+			expected: NewCompoundTag(NewTagName(`Compound`), NewCompoundPayload(
+				NewEndTag(),
+			)),
+			expectedErr: nil,
+		},
+		{
+			name:        `negative case: truncated`,
+			raw:         nbtCases[1].raw[:len(nbtCases[1].raw)-2],
+			paths:       []string{`Short`},
+			expected:    nil,
+			expectedErr: &NbtError{Op: "decode", Err: io.ErrUnexpectedEOF},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, r := range []io.Reader{bytes.NewBuffer(tt.raw), bufio.NewReader(bytes.NewBuffer(tt.raw))} {
+				actual, err := DecodeSelect(r, tt.paths...)
+
+				if tt.expectedErr == nil {
+					assert.NoError(t, err)
+					assert.Equal(t, tt.expected, actual)
+				} else {
+					assert.Error(t, err)
+					assert.Equal(t, tt.expectedErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeSelectWithOptions(t *testing.T) {
+	t.Run(`positive case: lazy`, func(t *testing.T) {
+		actual, err := DecodeSelectWithOptions(bytes.NewBuffer(nbtCases[0].raw), &DecodeOptions{Lazy: true}, `Hello World`)
+		assert.NoError(t, err)
+
+		tags := *actual.Payload().(*CompoundPayload)
+		assert.Len(t, tags, 2)
+		assert.IsType(t, new(RawTag), tags[0])
+		assert.Equal(t, TagName(`Hello World`), *tags[0].TagName())
+		assert.IsType(t, new(RawPayload), tags[0].Payload())
+	})
+
+	t.Run(`negative case: canceled`, func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		actual, err := DecodeSelectWithOptions(bytes.NewBuffer(nbtCases[1].raw), new(DecodeOptions).WithContext(ctx), `Short`)
+		assert.Nil(t, actual)
+		assert.Equal(t, &NbtError{Op: "decode", Err: context.Canceled}, err)
+	})
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"io"
+	"log/slog"
+)
+
+// NOTE: opts may be nil, its context is checked as in decoding
+func skipPayload(r io.Reader, typ TagType, opts *DecodeOptions) error {
+	return skipPayloadFrom(asByteReader(r, opts.logger()), typ, opts)
+}
+
+func skipPayloadFrom(br *byteReader, typ TagType, opts *DecodeOptions) error {
+	if logEnabled(br.logger, slog.LevelDebug) {
+		logDebug(br.logger, "skipping payload", "type", typ.String())
+	}
+
+	if size := fixedPayloadSize(typ); size > 0 {
+		return skipBytes(br, size)
+	}
+
+	switch typ {
+	case TagTypeByteArray:
+		return skipArray(br, 1)
+	case TagTypeString:
+		return skipString(br)
+	case TagTypeList:
+		return skipList(br, opts)
+	case TagTypeCompound:
+		return skipCompound(br, opts)
+	case TagTypeIntArray:
+		return skipArray(br, 4)
+	case TagTypeLongArray:
+		return skipArray(br, 8)
+	default:
+		err := &NbtError{Op: "decode", Err: ErrInvalidTagType}
+		logError(br.logger, "failed to skip", "type", typ, "error", err)
+		return err
+	}
+}
+
+func skipBytes(br *byteReader, n int64) error {
+	if err := br.skip(n); err != nil {
+		logError(br.logger, "failed to skip", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func skipString(br *byteReader) error {
+	l, err := readUint16(br)
+	if err != nil {
+		logError(br.logger, "failed to skip", "error", err)
+		return err
+	}
+
+	if err := skipBytes(br, int64(l)); err != nil {
+		logError(br.logger, "failed to skip", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func skipArray(br *byteReader, size int64) error {
+	l, err := readLength(br)
+	if err != nil {
+		logError(br.logger, "failed to skip", "error", err)
+		return err
+	}
+
+	if err := skipBytes(br, int64(l)*size); err != nil {
+		logError(br.logger, "failed to skip", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func skipList(br *byteReader, opts *DecodeOptions) error {
+	typ, err := readTagType(br)
+	if err != nil {
+		logError(br.logger, "failed to skip", "error", err)
+		return err
+	}
+
+	l, err := readLength(br)
+	if err != nil {
+		logError(br.logger, "failed to skip", "error", err)
+		return err
+	}
+
+	if l == 0 {
+		return nil
+	}
+
+	// NOTE: skip fixed size payloads at once
+	if size := fixedPayloadSize(typ); size > 0 {
+		if err := opts.checkContext(0); err != nil {
+			logError(br.logger, "failed to skip", "error", err)
+			return err
+		}
+
+		if err := skipBytes(br, int64(l)*size); err != nil {
+			logError(br.logger, "failed to skip", "error", err)
+			return err
+		}
+
+		return nil
+	}
+
+	for i := 0; i < l; i++ {
+		if err := opts.checkContext(i); err != nil {
+			logError(br.logger, "failed to skip", "error", err)
+			return err
+		}
+
+		if err := skipPayloadFrom(br, typ, opts); err != nil {
+			logError(br.logger, "failed to skip", "error", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func skipCompound(br *byteReader, opts *DecodeOptions) error {
+	for i := 0; ; i++ {
+		if err := opts.checkContext(i); err != nil {
+			logError(br.logger, "failed to skip", "error", err)
+			return err
+		}
+
+		var typ TagType
+		if err := typ.decode(br); err != nil {
+			logError(br.logger, "failed to skip", "error", err)
+			return err
+		}
+
+		if typ == TagTypeEnd {
+			return nil
+		}
+
+		if err := skipString(br); err != nil {
+			logError(br.logger, "failed to skip", "error", err)
+			return err
+		}
+
+		if err := skipPayloadFrom(br, typ, opts); err != nil {
+			logError(br.logger, "failed to skip", "error", err)
+			return err
+		}
+	}
+}
+
+func fixedPayloadSize(typ TagType) int64 {
+	switch typ {
+	case TagTypeByte:
+		return 1
+	case TagTypeShort:
+		return 2
+	case TagTypeInt, TagTypeFloat:
+		return 4
+	case TagTypeLong, TagTypeDouble:
+		return 8
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/Aton-Kish/gonbt/slices"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkipPayload(t *testing.T) {
+	type Case struct {
+		name        string
+		tagType     TagType
+		raw         []byte
+		expected    []byte
+		expectedErr error
+	}
+
+	trailing := []byte{0xDE, 0xAD, 0xBE, 0xEF}
+
+	cases := []Case{}
+
+	for _, c := range excludeEndTagCases {
+		cases = append(cases, Case{
+			name:        c.name,
+			tagType:     c.nbt.tagType,
+			raw:         slices.Concat(c.raw.payload, trailing),
+			expected:    trailing,
+			expectedErr: nil,
+		})
+	}
+
+	cases = append(cases, []Case{
+		{
+			name:        `negative case: invalid tag type`,
+			tagType:     TagType(0x0D),
+			raw:         trailing,
+			expected:    nil,
+			expectedErr: &NbtError{Op: "decode", Err: ErrInvalidTagType},
+		},
+		{
+			name:        `negative case: End`,
+			tagType:     TagTypeEnd,
+			raw:         trailing,
+			expected:    nil,
+			expectedErr: &NbtError{Op: "decode", Err: ErrInvalidTagType},
+		},
+		{
+			name:        `negative case: truncated`,
+			tagType:     TagTypeLongArray,
+			raw:         []byte{0x00, 0x00, 0x00, 0x01, 0x00},
+			expected:    nil,
+			expectedErr: &NbtError{Op: "decode", Err: io.ErrUnexpectedEOF},
+		},
+		{
+			name:        `negative case: negative length`,
+			tagType:     TagTypeIntArray,
+			raw:         []byte{0xFF, 0xFF, 0xFF, 0xFF},
+			expected:    nil,
+			expectedErr: &NbtError{Op: "decode", Err: ErrDecode},
+		},
+	}...)
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := bytes.NewBuffer(tt.raw)
+			err := skipPayload(buf, tt.tagType, nil)
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, buf.Bytes())
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestSkipPayload_logger(t *testing.T) {
+	restoreLogger(t)
+
+	global := new(bytes.Buffer)
+	SetSlogLogger(slog.New(slog.NewTextHandler(global, nil)))
+
+	buf := new(bytes.Buffer)
+	opts := &DecodeOptions{Logger: slog.New(slog.NewTextHandler(buf, nil))}
+
+	err := skipPayload(bytes.NewBuffer(nil), TagType(0x0D), opts)
+	assert.Error(t, err)
+	assert.Empty(t, global.String())
+	assert.Contains(t, buf.String(), `msg="failed to skip"`)
+}
@@ -26,14 +26,23 @@ import (
 )
 
 var (
-	ErrOutOfRange    = errors.New("out of range")
-	ErrStopIteration = errors.New("stop iteration")
-	ErrUnexpected    = errors.New("unexpected error")
+	ErrOutOfRange          = errors.New("out of range")
+	ErrStopIteration       = errors.New("stop iteration")
+	ErrUnexpected          = errors.New("unexpected error")
+	ErrUnexpectedEnd       = errors.New("unexpected end of input")
+	ErrUnterminatedString  = errors.New("unterminated string")
+	ErrUnterminatedComment = errors.New("unterminated block comment")
 )
 
 type SnbtError struct {
 	Op  string
 	Err error
+
+	// NOTE: Line is 0 when the position is unknown
+	Line    int
+	Column  int
+	Offset  int
+	Excerpt string
 }
 
 func (e *SnbtError) Error() string {
@@ -48,7 +57,15 @@ func (e *SnbtError) Error() string {
 		err = e.Err.Error()
 	}
 
-	return fmt.Sprintf("snbt %s: %s", e.Op, err)
+	if e.Line == 0 {
+		return fmt.Sprintf("snbt %s: %s", e.Op, err)
+	}
+
+	if e.Excerpt == "" {
+		return fmt.Sprintf("snbt %s: %s at line %d, column %d", e.Op, err, e.Line, e.Column)
+	}
+
+	return fmt.Sprintf("snbt %s: %s at line %d, column %d\n%s", e.Op, err, e.Line, e.Column, e.Excerpt)
 }
 
 func (e *SnbtError) Unwrap() error {
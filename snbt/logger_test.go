@@ -0,0 +1,60 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package snbt
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	liblog "github.com/Aton-Kish/gonbt/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetSlogLogger(t *testing.T) {
+	t.Cleanup(func() {
+		SetSlogLogger(slog.New(liblog.DiscardHandler))
+	})
+
+	buf := new(bytes.Buffer)
+	SetSlogLogger(slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	p := NewParser(`{a:1}`)
+	for p.Next() == nil {
+	}
+
+	assert.Contains(t, buf.String(), `level=DEBUG msg="next token" index=0 char={`)
+	assert.Contains(t, buf.String(), `level=DEBUG msg="no more tokens" index=5`)
+	assert.NotContains(t, buf.String(), "level=ERROR")
+}
+
+func TestParserOptions_Logger(t *testing.T) {
+	buf := new(bytes.Buffer)
+	p := NewParserWithOptions(`{a: 1}`, &ParserOptions{Logger: slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))})
+	assert.NoError(t, p.Compact())
+	assert.NotNil(t, p.Logger())
+
+	_, err := p.Char(-1)
+	assert.Error(t, err)
+
+	assert.Contains(t, buf.String(), `level=DEBUG msg="next token" index=0 char={`)
+	assert.Contains(t, buf.String(), `level=ERROR msg="failed to get character"`)
+}
@@ -22,6 +22,7 @@ package snbt
 
 import (
 	"errors"
+	"log/slog"
 	"strings"
 )
 
@@ -70,12 +71,40 @@ type Parser struct {
 	semicolonToken    bitmaps
 	prev              Token
 	curr              Token
+	logger            *slog.Logger
+	lenient           bool
+
+	// NOTE: set by parseToken, reported by Compact at openIndex
+	openErr   error
+	openIndex int
+
+	// NOTE: set by Compact, offsets maps each index of raw to its byte offset in source
+	source  []byte
+	offsets []int
+}
+
+type ParserOptions struct {
+	// NOTE: falls back to the logger set by SetSlogLogger or SetLogger when nil
+	Logger *slog.Logger
+
+	// NOTE: treats // and /* */ comments and trailing commas as spaces
+	Lenient bool
 }
 
 func NewParser(snbt string) *Parser {
+	return NewParserWithOptions(snbt, new(ParserOptions))
+}
+
+func NewParserWithOptions(snbt string, opts *ParserOptions) *Parser {
+	if opts == nil {
+		opts = new(ParserOptions)
+	}
+
 	p := new(Parser)
 	p.init(len(snbt))
 	p.raw = []byte(snbt)
+	p.logger = opts.Logger
+	p.lenient = opts.Lenient
 
 	p.parseToken()
 	p.parseMask()
@@ -135,8 +164,54 @@ func (p *Parser) parseToken() {
 	isDoubleQuoted := false
 	isEscaped := false
 
+	// NOTE: lenient only, comment is '/' or '*' while in a comment, and comma is the index of a comma that may be trailing
+	comment, start := byte(0), 0
+	comma, prev := -1, byte(0)
+
+	// NOTE: index of the quote that opened the current string
+	quote := -1
+
 	for i, c := range p.raw {
 		idx, pos := i/bitmapSize, i%bitmapSize
+
+		if p.lenient && !isSingleQuoted && !isDoubleQuoted {
+			if comment == 0 && c == '/' && i+1 < len(p.raw) && (p.raw[i+1] == '/' || p.raw[i+1] == '*') {
+				comment, start = p.raw[i+1], i
+			}
+
+			if comment != 0 {
+				p.spaceToken[idx] |= 1 << pos
+				if (comment == '/' && c == '\n') || (comment == '*' && c == '/' && i-start > 2 && p.raw[i-1] == '*') {
+					comment = 0
+				}
+
+				isEscaped = false
+				continue
+			}
+
+			switch c {
+			case ' ', '\t', '\n', '\r', '\f':
+			case ',':
+				comma = -1
+				if !strings.ContainsRune("{[,;", rune(prev)) {
+					comma = i
+				}
+			case '}', ']':
+				if comma >= 0 {
+					p.commaToken[comma/bitmapSize] &^= 1 << (comma % bitmapSize)
+					p.spaceToken[comma/bitmapSize] |= 1 << (comma % bitmapSize)
+				}
+
+				fallthrough
+			default:
+				comma = -1
+			}
+
+			if !strings.ContainsRune(" \t\n\r\f", rune(c)) {
+				prev = c
+			}
+		}
+
 		switch c {
 		case '\\':
 			if !isEscaped {
@@ -149,6 +224,7 @@ func (p *Parser) parseToken() {
 			}
 
 			isSingleQuoted = !isSingleQuoted
+			quote = i
 			p.quoteToken[idx] |= 1 << pos
 		case '"':
 			if isEscaped || isSingleQuoted {
@@ -156,6 +232,7 @@ func (p *Parser) parseToken() {
 			}
 
 			isDoubleQuoted = !isDoubleQuoted
+			quote = i
 			p.quoteToken[idx] |= 1 << pos
 		case ' ', '\t', '\n', '\r', '\f':
 			p.spaceToken[idx] |= 1 << pos
@@ -177,6 +254,14 @@ func (p *Parser) parseToken() {
 
 		isEscaped = false
 	}
+
+	if isSingleQuoted || isDoubleQuoted {
+		p.openErr, p.openIndex = ErrUnterminatedString, quote
+	}
+
+	if comment == '*' {
+		p.openErr, p.openIndex = ErrUnterminatedComment, start
+	}
 }
 
 func (p *Parser) parseMask() {
@@ -210,7 +295,7 @@ func (p *Parser) parseMask() {
 func (p *Parser) Char(index int) (rune, error) {
 	if index < 0 || index >= len(p.raw) {
 		err := &SnbtError{Op: "char", Err: ErrOutOfRange}
-		logger.Println("failed to get character", "func", getFuncName(), "error", err)
+		logError(p.logger, "failed to get character", "error", err)
 		return *new(rune), err
 	}
 
@@ -220,13 +305,17 @@ func (p *Parser) Char(index int) (rune, error) {
 func (p *Parser) Slice(start int, end int) ([]byte, error) {
 	if start < 0 || start > len(p.raw) || end < 0 || end > len(p.raw) || start > end {
 		err := &SnbtError{Op: "slice", Err: ErrOutOfRange}
-		logger.Println("failed to get slice", "func", getFuncName(), "error", err)
+		logError(p.logger, "failed to get slice", "error", err)
 		return nil, err
 	}
 
 	return p.raw[start:end], nil
 }
 
+func (p *Parser) Logger() *slog.Logger {
+	return p.logger
+}
+
 func (p *Parser) PrevToken() *Token {
 	return &p.prev
 }
@@ -247,7 +336,7 @@ func (p *Parser) next(optFns ...func(options *parseOptions) error) error {
 	for _, optFn := range optFns {
 		if err := optFn(&options); err != nil {
 			err = &SnbtError{Op: "next", Err: err}
-			logger.Println("failed to next", "func", getFuncName(), "error", err)
+			logError(p.logger, "failed to next", "error", err)
 			return err
 		}
 	}
@@ -318,14 +407,21 @@ func (p *Parser) next(optFns ...func(options *parseOptions) error) error {
 
 	if index == l || !strings.ContainsRune(`" {}[],:;`, token) {
 		err := &SnbtError{Op: "next", Err: ErrStopIteration}
-		logger.Println("failed to next", "func", getFuncName(), "error", err)
+		if logEnabled(p.logger, slog.LevelDebug) {
+			logDebug(p.logger, "no more tokens", "index", index)
+		}
+
 		return err
 	}
 
+	if logEnabled(p.logger, slog.LevelDebug) {
+		logDebug(p.logger, "next token", "index", index, "char", string(token))
+	}
+
 	bitmaps := p.tokenBitmaps(token)
 	if bitmaps == nil {
 		err := &SnbtError{Op: "next", Err: ErrUnexpected}
-		logger.Println("failed to next", "func", getFuncName(), "error", err)
+		logError(p.logger, "failed to next", "error", err)
 		return err
 	}
 
@@ -336,7 +432,12 @@ func (p *Parser) next(optFns ...func(options *parseOptions) error) error {
 }
 
 func (p *Parser) Compact() error {
-	orgp := NewParser(string(p.raw))
+	orgp := NewParserWithOptions(string(p.raw), &ParserOptions{Logger: p.logger, Lenient: p.lenient})
+	if orgp.openErr != nil {
+		err := p.ErrorAt(orgp.openIndex, "compact", orgp.openErr)
+		logError(p.logger, "failed to compact", "error", err)
+		return err
+	}
 
 	dataMask := make(bitmaps, len(orgp.spaceToken))
 	copy(dataMask, orgp.spaceToken)
@@ -349,6 +450,10 @@ func (p *Parser) Compact() error {
 	comp := new(Parser)
 	cl := len(orgp.raw) - popCount(orgp.spaceToken...)
 	comp.init(cl)
+	comp.logger = p.logger
+	comp.lenient = p.lenient
+	comp.source = p.original()
+	comp.offsets = make([]int, cl)
 
 	ci := 0
 
@@ -362,8 +467,8 @@ func (p *Parser) Compact() error {
 			if i == orgp.CurrToken().Index() {
 				bitmaps := comp.tokenBitmaps(orgp.CurrToken().Char())
 				if bitmaps == nil {
-					err := &SnbtError{Op: "compact", Err: ErrUnexpected}
-					logger.Println("failed to compact", "func", getFuncName(), "error", err)
+					err := p.ErrorAt(i, "compact", ErrUnexpected)
+					logError(p.logger, "failed to compact", "error", err)
 					return err
 				}
 
@@ -371,13 +476,14 @@ func (p *Parser) Compact() error {
 				(*bitmaps)[cidx] |= 1 << cpos
 
 				if err := orgp.next(optFn); err != nil && !errors.Is(err, ErrStopIteration) {
-					err = &SnbtError{Op: "compact", Err: err}
-					logger.Println("failed to compact", "func", getFuncName(), "error", err)
+					err = p.ErrorAt(i, "compact", err)
+					logError(p.logger, "failed to compact", "error", err)
 					return err
 				}
 			}
 
 			comp.raw[ci] = orgp.raw[i]
+			comp.offsets[ci] = p.Offset(i)
 			if ci++; ci == cl {
 				break
 			}
@@ -502,6 +502,9 @@ func TestParser_Compact(t *testing.T) {
 
 			if tt.expectedErr == nil {
 				assert.NoError(t, err)
+
+				// NOTE: positions in the original text are covered by TestParser_Position
+				tt.parser.source, tt.parser.offsets = nil, nil
 				assert.Equal(t, tt.expected, tt.parser)
 			} else {
 				assert.Error(t, err)
@@ -510,3 +513,120 @@ func TestParser_Compact(t *testing.T) {
 		})
 	}
 }
+
+func TestParser_Compact_lenient(t *testing.T) {
+	lenient := &ParserOptions{Lenient: true}
+
+	cases := []struct {
+		name     string
+		parser   *Parser
+		expected *Parser
+	}{
+		{
+			name:     `positive case: line comment`,
+			parser:   NewParserWithOptions("{\n  // it's a \"comment\"\n  Name: \"Steve\" // , }\n}", lenient),
+			expected: NewParserWithOptions(`{Name:"Steve"}`, lenient),
+		},
+		{
+			name:     `positive case: block comment`,
+			parser:   NewParserWithOptions(`{/**/Name: /*/ [ */ "Steve"}`, lenient),
+			expected: NewParserWithOptions(`{Name:"Steve"}`, lenient),
+		},
+		{
+			name:     `positive case: comment in string`,
+			parser:   NewParserWithOptions(`{Url: "http://example.com/*"}`, lenient),
+			expected: NewParserWithOptions(`{Url:"http://example.com/*"}`, lenient),
+		},
+		{
+			name:     `positive case: trailing comma`,
+			parser:   NewParserWithOptions(`{List: [B; 0b, 1b, ], Name: "Steve",}`, lenient),
+			expected: NewParserWithOptions(`{List:[B;0b,1b],Name:"Steve"}`, lenient),
+		},
+		{
+			name:     `positive case: trailing comma before comment`,
+			parser:   NewParserWithOptions("[1b, // last\n]", lenient),
+			expected: NewParserWithOptions(`[1b]`, lenient),
+		},
+		{
+			name:     `positive case: lone comma`,
+			parser:   NewParserWithOptions(`[,]`, lenient),
+			expected: NewParserWithOptions(`[,]`, lenient),
+		},
+		{
+			name:     `positive case: strict`,
+			parser:   NewParser(`{Name: "Steve", /**/}`),
+			expected: NewParser(`{Name:"Steve",/**/}`),
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.parser.Compact()
+			assert.NoError(t, err)
+
+			tt.parser.source, tt.parser.offsets = nil, nil
+			assert.Equal(t, tt.expected, tt.parser)
+		})
+	}
+}
+
+func TestParser_Compact_unterminated(t *testing.T) {
+	cases := []struct {
+		name     string
+		parser   *Parser
+		expected *SnbtError
+	}{
+		{
+			name:   `negative case: double quoted`,
+			parser: NewParser(`{Name: "Steve}`),
+			expected: &SnbtError{
+				Op:      "compact",
+				Err:     ErrUnterminatedString,
+				Line:    1,
+				Column:  8,
+				Offset:  7,
+				Excerpt: "{Name: \"Steve}\n       ^",
+			},
+		},
+		{
+			name:   `negative case: single quoted after a closed string`,
+			parser: NewParser("{\n  A: \"x\",\n  B: 'y\\'}"),
+			expected: &SnbtError{
+				Op:      "compact",
+				Err:     ErrUnterminatedString,
+				Line:    3,
+				Column:  6,
+				Offset:  17,
+				Excerpt: "  B: 'y\\'}\n     ^",
+			},
+		},
+		{
+			name:   `negative case: lenient block comment`,
+			parser: NewParserWithOptions(`{Name: "Steve" /* */ /* name}`, &ParserOptions{Lenient: true}),
+			expected: &SnbtError{
+				Op:      "compact",
+				Err:     ErrUnterminatedComment,
+				Line:    1,
+				Column:  22,
+				Offset:  21,
+				Excerpt: "{Name: \"Steve\" /* */ /* name}\n                     ^",
+			},
+		},
+		{
+			name:     `positive case: strict block comment`,
+			parser:   NewParser(`{Name: "Steve" /* name}`),
+			expected: nil,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.parser.Compact()
+			if tt.expected == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, tt.expected, err)
+			}
+		})
+	}
+}
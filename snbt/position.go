@@ -0,0 +1,141 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package snbt
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+// NOTE: the number of runes kept on each side of the caret in an excerpt
+const excerptWidth = 32
+
+// NOTE: maps an index into the possibly compacted input back to a byte offset into the original text
+func (p *Parser) Offset(index int) int {
+	if p.offsets == nil {
+		return clamp(index, 0, len(p.raw))
+	}
+
+	if index < 0 {
+		return 0
+	}
+
+	if index >= len(p.offsets) {
+		return len(p.source)
+	}
+
+	return p.offsets[index]
+}
+
+// NOTE: line and column are 1-based, the column counts runes
+func (p *Parser) Position(index int) (line int, column int, offset int) {
+	src := p.original()
+	offset = p.Offset(index)
+
+	start := bytes.LastIndexByte(src[:offset], '\n') + 1
+	line = bytes.Count(src[:start], []byte{'\n'}) + 1
+	column = utf8.RuneCount(src[start:offset]) + 1
+
+	return line, column, offset
+}
+
+// NOTE: returns the first index in (start, end) that follows whitespace removed by Compact, or end when there is none
+func (p *Parser) Gap(start int, end int) int {
+	if p.offsets == nil || start < 0 || end > len(p.offsets) {
+		return end
+	}
+
+	for i := start + 1; i < end; i++ {
+		if p.offsets[i] != p.offsets[i-1]+1 {
+			return i
+		}
+	}
+
+	return end
+}
+
+func (p *Parser) ErrorAt(index int, op string, err error) *SnbtError {
+	line, column, offset := p.Position(index)
+
+	return &SnbtError{
+		Op:      op,
+		Err:     err,
+		Line:    line,
+		Column:  column,
+		Offset:  offset,
+		Excerpt: excerpt(p.original(), offset),
+	}
+}
+
+func (p *Parser) original() []byte {
+	if p.source == nil {
+		return p.raw
+	}
+
+	return p.source
+}
+
+func excerpt(src []byte, offset int) string {
+	start := bytes.LastIndexByte(src[:offset], '\n') + 1
+	end := len(src)
+	if i := bytes.IndexByte(src[offset:], '\n'); i >= 0 {
+		end = offset + i
+	}
+
+	before := []rune(strings.TrimRight(string(src[start:offset]), "\r"))
+	after := []rune(strings.TrimRight(string(src[offset:end]), "\r"))
+
+	prefix, suffix := "", ""
+	if len(before) > excerptWidth {
+		before = before[len(before)-excerptWidth:]
+		prefix = "..."
+	}
+
+	if len(after) > excerptWidth {
+		after = after[:excerptWidth]
+		suffix = "..."
+	}
+
+	// NOTE: keep tabs so that the caret lines up with the text above it
+	pad := []rune(strings.Repeat(" ", len(prefix)))
+	for _, r := range before {
+		if r == '\t' {
+			pad = append(pad, '\t')
+		} else {
+			pad = append(pad, ' ')
+		}
+	}
+
+	return prefix + string(before) + string(after) + suffix + "\n" + string(pad) + "^"
+}
+
+func clamp(v int, lo int, hi int) int {
+	if v < lo {
+		return lo
+	}
+
+	if v > hi {
+		return hi
+	}
+
+	return v
+}
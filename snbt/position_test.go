@@ -0,0 +1,194 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package snbt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser_Position(t *testing.T) {
+	src := "{\n  Name: \"Steve\",\n\tList: [1b, 2b]\n}"
+
+	cases := []struct {
+		name           string
+		compact        bool
+		index          int
+		expectedLine   int
+		expectedColumn int
+		expectedOffset int
+	}{
+		{
+			name:           `positive case: first character`,
+			index:          0,
+			expectedLine:   1,
+			expectedColumn: 1,
+			expectedOffset: 0,
+		},
+		{
+			name:           `positive case: second line`,
+			index:          4,
+			expectedLine:   2,
+			expectedColumn: 3,
+			expectedOffset: 4,
+		},
+		{
+			name:           `positive case: compacted - second line`,
+			compact:        true,
+			index:          1,
+			expectedLine:   2,
+			expectedColumn: 3,
+			expectedOffset: 4,
+		},
+		{
+			name:           `positive case: compacted - third line after a tab`,
+			compact:        true,
+			index:          len(`{Name:"Steve",List:[1b,`),
+			expectedLine:   3,
+			expectedColumn: 13,
+			expectedOffset: len("{\n  Name: \"Steve\",\n\tList: [1b, "),
+		},
+		{
+			name:           `positive case: compacted - end of input`,
+			compact:        true,
+			index:          len(`{Name:"Steve",List:[1b,2b]}`),
+			expectedLine:   4,
+			expectedColumn: 2,
+			expectedOffset: len(src),
+		},
+		{
+			name:           `positive case: negative index`,
+			index:          -1,
+			expectedLine:   1,
+			expectedColumn: 1,
+			expectedOffset: 0,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParser(src)
+			if tt.compact {
+				assert.NoError(t, p.Compact())
+			}
+
+			line, column, offset := p.Position(tt.index)
+			assert.Equal(t, tt.expectedLine, line)
+			assert.Equal(t, tt.expectedColumn, column)
+			assert.Equal(t, tt.expectedOffset, offset)
+		})
+	}
+}
+
+func TestParser_ErrorAt(t *testing.T) {
+	cases := []struct {
+		name     string
+		snbt     string
+		index    int
+		expected *SnbtError
+	}{
+		{
+			name:  `positive case: single line`,
+			snbt:  `{a: 1b, b: x}`,
+			index: len(`{a:1b,b:`),
+			expected: &SnbtError{
+				Op:      "parse",
+				Err:     ErrUnexpected,
+				Line:    1,
+				Column:  12,
+				Offset:  11,
+				Excerpt: "{a: 1b, b: x}\n           ^",
+			},
+		},
+		{
+			name:  `positive case: tab indented`,
+			snbt:  "{\n\ta: x\n}",
+			index: len(`{a:`),
+			expected: &SnbtError{
+				Op:      "parse",
+				Err:     ErrUnexpected,
+				Line:    2,
+				Column:  5,
+				Offset:  6,
+				Excerpt: "\ta: x\n\t   ^",
+			},
+		},
+		{
+			name:  `positive case: long line`,
+			snbt:  `{Items: [` + "0b, 1b, 2b, 3b, 4b, 5b, 6b, 7b, 8b, 9b, " + `x, 10b, 11b, 12b, 13b, 14b, 15b, 16b, 17b, 18b]}`,
+			index: len(`{Items:[0b,1b,2b,3b,4b,5b,6b,7b,8b,9b,`),
+			expected: &SnbtError{
+				Op:      "parse",
+				Err:     ErrUnexpected,
+				Line:    1,
+				Column:  50,
+				Offset:  49,
+				Excerpt: "...2b, 3b, 4b, 5b, 6b, 7b, 8b, 9b, x, 10b, 11b, 12b, 13b, 14b, 15b,...\n" + strings.Repeat(" ", 35) + "^",
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParser(tt.snbt)
+			assert.NoError(t, p.Compact())
+
+			err := p.ErrorAt(tt.index, "parse", ErrUnexpected)
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func TestParser_Gap(t *testing.T) {
+	p := NewParser("{\n  Count 1b,\n  id: \"a b\"\n}")
+	assert.NoError(t, p.Compact())
+
+	cases := []struct {
+		name     string
+		start    int
+		end      int
+		expected int
+	}{
+		{name: `positive case: gap`, start: len(`{`), end: len(`{Count1b`), expected: len(`{Count`)},
+		{name: `positive case: no gap`, start: len(`{Count1b,`), end: len(`{Count1b,id`), expected: len(`{Count1b,id`)},
+		{name: `positive case: quoted`, start: len(`{Count1b,id:`), end: len(`{Count1b,id:"a b"`), expected: len(`{Count1b,id:"a b"`)},
+		{name: `negative case: out of range`, start: -1, end: 100, expected: 100},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, p.Gap(tt.start, tt.end))
+		})
+	}
+}
+
+func TestSnbtError_Error(t *testing.T) {
+	err := &SnbtError{Op: "parse", Err: ErrUnexpected, Line: 2, Column: 5, Offset: 5}
+	assert.Equal(t, "snbt parse: unexpected error at line 2, column 5", err.Error())
+
+	err = &SnbtError{Op: "parse", Err: ErrUnexpected, Line: 1, Column: 4, Offset: 3, Excerpt: "{a: x}\n    ^"}
+	assert.Equal(t, "snbt parse: unexpected error at line 1, column 4\n{a: x}\n    ^", err.Error())
+
+	err = &SnbtError{Op: "parse", Err: ErrUnexpected}
+	assert.Equal(t, "snbt parse: unexpected error", err.Error())
+}
@@ -0,0 +1,439 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+const snbtFlushSize = 32 * 1024
+
+var snbtBuffers = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, bufferSize)
+		return &b
+	},
+}
+
+// NOTE: streams the same text as StringifyWithOptions, payloads implemented outside this package are appended whole
+func WriteSNBT(w io.Writer, tag Tag, opts *StringifyOptions) error {
+	if opts == nil {
+		opts = new(StringifyOptions)
+	}
+
+	bp := snbtBuffers.Get().(*[]byte)
+
+	sw := &snbtWriter{w: w, buf: (*bp)[:0], opts: opts, palette: opts.palette(w)}
+	sw.root(tag)
+
+	if opts.TrailingNewline {
+		sw.buf = append(sw.buf, '\n')
+	}
+
+	sw.flush()
+
+	if cap(sw.buf) <= 4*bufferSize {
+		*bp = sw.buf[:0]
+		snbtBuffers.Put(bp)
+	}
+
+	if sw.err != nil {
+		err := &NbtError{Op: "stringify", Err: sw.err}
+		logError(opts.logger(), "failed to stringify", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// NOTE: without w, snbtWriter only appends to buf
+type snbtWriter struct {
+	w    io.Writer
+	buf  []byte
+	opts *StringifyOptions
+	err  error
+
+	// NOTE: set while measuring a one-line form, over reports that buf grew past limit
+	limit  int
+	over   bool
+	inline *StringifyOptions
+
+	palette *Palette
+	scratch []byte
+}
+
+func appendSNBT(dst []byte, p Payload, opts *StringifyOptions, depth int) []byte {
+	if opts == nil {
+		opts = new(StringifyOptions)
+	}
+
+	sw := &snbtWriter{buf: dst, opts: opts}
+	sw.payload(p, depth)
+
+	return sw.buf
+}
+
+func (sw *snbtWriter) flush() {
+	if sw.w == nil {
+		return
+	}
+
+	if sw.err == nil && len(sw.buf) > 0 {
+		_, sw.err = sw.w.Write(sw.buf)
+	}
+
+	sw.buf = sw.buf[:0]
+}
+
+func (sw *snbtWriter) maybeFlush() {
+	if sw.w != nil && len(sw.buf) >= snbtFlushSize {
+		sw.flush()
+	}
+}
+
+func (sw *snbtWriter) exceeded() bool {
+	if sw.limit > 0 && len(sw.buf) > sw.limit {
+		sw.over = true
+	}
+
+	return sw.over
+}
+
+// NOTE: writes p on one line when that takes at most CollapseWidth bytes
+func (sw *snbtWriter) collapse(p Payload, depth int) bool {
+	if sw.opts.Indent == "" || sw.opts.CollapseWidth <= 0 {
+		return false
+	}
+
+	if sw.inline == nil {
+		inline := *sw.opts
+		inline.Indent = ""
+		sw.inline = &inline
+	}
+
+	start := len(sw.buf)
+
+	m := &snbtWriter{buf: sw.buf, opts: sw.inline, limit: start + sw.opts.CollapseWidth}
+	m.payload(p, depth)
+
+	if m.err != nil && sw.err == nil {
+		sw.err = m.err
+	}
+
+	if m.exceeded() {
+		sw.buf = m.buf[:start]
+		return false
+	}
+
+	sw.buf = m.buf
+
+	// NOTE: measured without colors, so escape sequences don't count towards the width
+	if sw.palette != nil {
+		c := &snbtWriter{buf: sw.buf[:start], opts: sw.inline, palette: sw.palette}
+		c.payload(p, depth)
+		sw.buf = c.buf
+	}
+
+	return true
+}
+
+func (sw *snbtWriter) newline(depth int) {
+	sw.buf = append(sw.buf, '\n')
+	for i := 0; i < depth; i++ {
+		sw.buf = append(sw.buf, sw.opts.Indent...)
+	}
+}
+
+func (sw *snbtWriter) root(tag Tag) {
+	rootName := ""
+	if tag.TagName() != nil {
+		rootName = string(*tag.TagName())
+	}
+
+	if rootName != "" {
+		sw.bracket('{')
+		if sw.opts.Indent != "" {
+			sw.newline(1)
+		}
+
+		sw.tag(tag, 1)
+
+		if sw.opts.Indent != "" {
+			sw.newline(0)
+		}
+
+		sw.bracket('}')
+
+		return
+	}
+
+	if tag.TypeId() == TagTypeEnd {
+		return
+	}
+
+	if !isBuiltinTag(tag) {
+		b := tag.AppendSNBT(nil, sw.opts, 0)
+		b = bytes.TrimLeft(b[bytes.IndexByte(b, ':')+1:], sw.opts.Space)
+		sw.buf = append(sw.buf, b...)
+		return
+	}
+
+	sw.payload(tag.Payload(), 0)
+}
+
+func isBuiltinTag(tag Tag) bool {
+	switch tag.(type) {
+	case snbtTag, *RawTag:
+		return true
+	default:
+		return false
+	}
+}
+
+func (sw *snbtWriter) tag(tag Tag, depth int) {
+	if tag.TypeId() == TagTypeEnd {
+		return
+	}
+
+	if !isBuiltinTag(tag) {
+		sw.buf = tag.AppendSNBT(sw.buf, sw.opts, depth)
+		return
+	}
+
+	if sw.palette != nil {
+		sw.scratch = tag.TagName().appendSNBT(sw.scratch[:0], sw.opts)
+		sw.colored(sw.palette.Key, sw.scratch)
+	} else {
+		sw.buf = tag.TagName().appendSNBT(sw.buf, sw.opts)
+	}
+
+	sw.buf = append(sw.buf, ':')
+	sw.buf = append(sw.buf, sw.opts.Space...)
+
+	if sw.opts.Booleans == BooleanStyleKnownKeys && tag.TypeId() == TagTypeByte && sw.opts.isBooleanKey(string(*tag.TagName())) {
+		if p, ok := tag.Payload().(*BytePayload); ok {
+			if b, ok := appendBoolean(sw.scratch[:0], int8(*p)); ok {
+				sw.scratch = b
+				sw.number(b)
+				return
+			}
+		}
+	}
+
+	sw.payload(tag.Payload(), depth)
+}
+
+func (sw *snbtWriter) payload(p Payload, depth int) {
+	switch p := p.(type) {
+	case *CompoundPayload:
+		sw.compound(p, depth)
+	case *ListPayload:
+		sw.list(p, depth)
+	case *ByteArrayPayload:
+		sw.array(p, 'B', len(*p), depth, func(i int) {
+			sw.scratch = append(strconv.AppendInt(sw.scratch[:0], int64((*p)[i]), 10), 'b')
+			sw.number(sw.scratch)
+		})
+	case *IntArrayPayload:
+		sw.array(p, 'I', len(*p), depth, func(i int) {
+			sw.scratch = strconv.AppendInt(sw.scratch[:0], int64((*p)[i]), 10)
+			sw.number(sw.scratch)
+		})
+	case *LongArrayPayload:
+		sw.array(p, 'L', len(*p), depth, func(i int) {
+			sw.scratch = append(strconv.AppendInt(sw.scratch[:0], (*p)[i], 10), 'L')
+			sw.number(sw.scratch)
+		})
+	case *RawPayload:
+		decoded, err := p.Decode(new(DecodeOptions))
+		if err != nil {
+			logError(sw.opts.logger(), "failed to stringify", "error", err)
+			if sw.err == nil {
+				sw.err = err
+			}
+
+			return
+		}
+
+		sw.payload(decoded, depth)
+	case *BytePayload, *ShortPayload, *IntPayload, *LongPayload, *FloatPayload, *DoublePayload:
+		sw.scratch = p.AppendSNBT(sw.scratch[:0], sw.opts, depth)
+		sw.number(sw.scratch)
+	case *StringPayload:
+		if sw.palette == nil {
+			sw.buf = p.AppendSNBT(sw.buf, sw.opts, depth)
+			return
+		}
+
+		sw.scratch = p.AppendSNBT(sw.scratch[:0], sw.opts, depth)
+		sw.colored(sw.palette.String, sw.scratch)
+	default:
+		sw.buf = p.AppendSNBT(sw.buf, sw.opts, depth)
+	}
+}
+
+func (sw *snbtWriter) array(p Payload, prefix byte, l int, depth int, elem func(i int)) {
+	if sw.collapse(p, depth) {
+		return
+	}
+
+	wrap := 0
+	if sw.opts.Indent != "" && l > 0 {
+		wrap = sw.opts.ArrayWrap
+	}
+
+	sw.bracket('[')
+	if sw.palette != nil {
+		sw.colored(sw.palette.Suffix, []byte{prefix})
+	} else {
+		sw.buf = append(sw.buf, prefix)
+	}
+
+	sw.buf = append(sw.buf, ';')
+	if wrap > 0 {
+		sw.newline(depth + 1)
+	} else {
+		sw.buf = append(sw.buf, sw.opts.Space...)
+	}
+
+	for i := 0; i < l; i++ {
+		if i > 0 {
+			sw.buf = append(sw.buf, ',')
+			if wrap > 0 && i%wrap == 0 {
+				sw.newline(depth + 1)
+			} else {
+				sw.buf = append(sw.buf, sw.opts.Space...)
+			}
+		}
+
+		elem(i)
+		if sw.exceeded() {
+			return
+		}
+
+		sw.maybeFlush()
+	}
+
+	if wrap > 0 {
+		sw.newline(depth)
+	}
+
+	sw.bracket(']')
+}
+
+func (sw *snbtWriter) list(p *ListPayload, depth int) {
+	if p.Len() > 0 && sw.collapse(p, depth) {
+		return
+	}
+
+	sw.bracket('[')
+
+	for i, payload := range p.Values() {
+		if i > 0 {
+			sw.buf = append(sw.buf, ',')
+		}
+
+		if sw.opts.Indent != "" {
+			sw.newline(depth + 1)
+		} else if i > 0 {
+			sw.buf = append(sw.buf, sw.opts.Space...)
+		}
+
+		sw.payload(payload, depth+1)
+		if sw.exceeded() {
+			return
+		}
+
+		sw.maybeFlush()
+	}
+
+	if sw.opts.Indent != "" && p.Len() > 0 {
+		sw.newline(depth)
+	}
+
+	sw.bracket(']')
+}
+
+type snbtEntry struct {
+	tag Tag
+	key []byte
+}
+
+// NOTE: keys are compared as their rendered name followed by ':', as the whole rendered entries used to be
+func (sw *snbtWriter) compound(p *CompoundPayload, depth int) {
+	entries := make([]snbtEntry, 0, len(*p))
+	for _, tag := range *p {
+		if tag.TypeId() == TagTypeEnd {
+			break
+		}
+
+		var key []byte
+		if sw.opts.KeyOrder != KeyOrderInsertion {
+			key = append(tag.TagName().appendSNBT(nil, sw.opts), ':')
+		}
+
+		entries = append(entries, snbtEntry{tag: tag, key: key})
+	}
+
+	switch sw.opts.KeyOrder {
+	case KeyOrderLexicographic:
+		sort.SliceStable(entries, func(i, j int) bool { return bytes.Compare(entries[i].key, entries[j].key) < 0 })
+	case KeyOrderType:
+		sort.SliceStable(entries, func(i, j int) bool {
+			if ti, tj := entries[i].tag.TypeId(), entries[j].tag.TypeId(); ti != tj {
+				return ti < tj
+			}
+
+			return bytes.Compare(entries[i].key, entries[j].key) < 0
+		})
+	}
+
+	sw.bracket('{')
+
+	for i, entry := range entries {
+		if i > 0 {
+			sw.buf = append(sw.buf, ',')
+		}
+
+		if sw.opts.Indent != "" {
+			sw.newline(depth + 1)
+		} else if i > 0 {
+			sw.buf = append(sw.buf, sw.opts.Space...)
+		}
+
+		sw.tag(entry.tag, depth+1)
+		if sw.exceeded() {
+			return
+		}
+
+		sw.maybeFlush()
+	}
+
+	if sw.opts.Indent != "" && len(entries) > 0 {
+		sw.newline(depth)
+	}
+
+	sw.bracket('}')
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingWriter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(p)
+}
+
+type failingWriter struct{}
+
+var errFailingWriter = errors.New("failing writer")
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	return 0, errFailingWriter
+}
+
+func TestWriteSNBT(t *testing.T) {
+	for _, c := range nbtCases {
+		t.Run(c.name, func(t *testing.T) {
+			for _, opts := range []*StringifyOptions{{Space: " "}, {}, {Space: " ", Indent: "  "}} {
+				buf := new(bytes.Buffer)
+				err := WriteSNBT(buf, c.nbt, opts)
+				assert.NoError(t, err)
+				assert.Equal(t, StringifyWithOptions(c.nbt, opts), buf.String())
+			}
+
+			buf := new(bytes.Buffer)
+			assert.NoError(t, WriteSNBT(buf, c.nbt, &StringifyOptions{Space: " "}))
+			assert.Equal(t, c.snbt.typeDefault, buf.String())
+		})
+	}
+}
+
+func TestWriteSNBT_flush(t *testing.T) {
+	tag := NewCompoundTag(NewTagName(``), NewCompoundPayload(
+		NewLongArrayTag(NewTagName(`data`), NewLongArrayPayload(make([]int64, 16*1024)...)),
+		NewEndTag(),
+	))
+
+	w := new(countingWriter)
+	err := WriteSNBT(w, tag, &StringifyOptions{Space: " "})
+	assert.NoError(t, err)
+	assert.Greater(t, w.writes, 1)
+	assert.Equal(t, `{data: [L; 0L`+strings.Repeat(`, 0L`, 16*1024-1)+`]}`, w.String())
+}
+
+func TestWriteSNBT_deep(t *testing.T) {
+	const depth = 2000
+
+	payload := Payload(NewIntPayload(1))
+	for i := 0; i < depth; i++ {
+		payload = NewListPayload(payload)
+	}
+
+	tag := NewListTag(NewTagName(``), payload.(*ListPayload))
+
+	buf := new(bytes.Buffer)
+	err := WriteSNBT(buf, tag, &StringifyOptions{Space: " ", Indent: " "})
+	assert.NoError(t, err)
+	assert.Equal(t, depth*depth, strings.Count(buf.String(), " "))
+}
+
+func TestWriteSNBT_error(t *testing.T) {
+	err := WriteSNBT(new(failingWriter), nbtCases[0].nbt, &StringifyOptions{})
+	assert.True(t, errors.Is(err, errFailingWriter))
+}
@@ -21,8 +21,11 @@
 package nbt
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"strings"
 
 	"github.com/Aton-Kish/gonbt/snbt"
@@ -33,11 +36,15 @@ type Tag interface {
 	TypeId() TagType
 	TagName() *TagName
 	Payload() Payload
-	encode(w io.Writer) error
-	decode(r io.Reader) error
-	stringify(space string, indent string, depth int) string
+	NbtEncoder
+	NbtDecoder
+	SnbtAppender
+	JsonAppender
+}
+
+type snbtTag interface {
+	Tag
 	parse(parser *snbt.Parser) error
-	json(space string, indent string, depth int) string
 }
 
 func NewTag(typ TagType) (Tag, error) {
@@ -70,64 +77,88 @@ func NewTag(typ TagType) (Tag, error) {
 		return NewLongArrayTag(new(TagName), new(LongArrayPayload)), nil
 	default:
 		err := &NbtError{Op: "new", Err: ErrInvalidTagType}
-		logger.Println("failed to new", "func", getFuncName(), "error", err)
+		logError(nil, "failed to new", "error", err)
 		return nil, err
 	}
 }
 
-func newTagFromSnbt(parser *snbt.Parser) (Tag, error) {
+func newTagFromSnbt(parser *snbt.Parser) (snbtTag, error) {
 	var name TagName
 	// NOTE: skip if nameless root
 	if parser.CurrToken().Index() > 0 {
 		if err := name.parse(parser); err != nil {
 			err = &NbtError{Op: "new", Err: err}
-			logger.Println("failed to new", "func", getFuncName(), "error", err)
+			logError(parser.Logger(), "failed to new", "error", err)
 			return nil, err
 		}
 	}
 
 	p, err := newPayloadFromSnbt(parser)
 	if err != nil {
-		logger.Println("failed to new", "func", getFuncName(), "error", err)
+		logError(parser.Logger(), "failed to new", "error", err)
+		return nil, err
+	}
+
+	tag, err := newTagWithPayload(&name, p)
+	if err != nil {
+		err = &NbtError{Op: "new", Err: ErrInvalidSnbtFormat}
+		logError(parser.Logger(), "failed to new", "error", err)
 		return nil, err
 	}
 
-	switch payload := p.(type) {
+	return tag, nil
+}
+
+func newTagWithPayload(tagName *TagName, payload Payload) (snbtTag, error) {
+	switch payload := payload.(type) {
 	case *BytePayload:
-		return NewByteTag(&name, payload), nil
+		return NewByteTag(tagName, payload), nil
 	case *ShortPayload:
-		return NewShortTag(&name, payload), nil
+		return NewShortTag(tagName, payload), nil
 	case *IntPayload:
-		return NewIntTag(&name, payload), nil
+		return NewIntTag(tagName, payload), nil
 	case *LongPayload:
-		return NewLongTag(&name, payload), nil
+		return NewLongTag(tagName, payload), nil
 	case *FloatPayload:
-		return NewFloatTag(&name, payload), nil
+		return NewFloatTag(tagName, payload), nil
 	case *DoublePayload:
-		return NewDoubleTag(&name, payload), nil
+		return NewDoubleTag(tagName, payload), nil
 	case *ByteArrayPayload:
-		return NewByteArrayTag(&name, payload), nil
+		return NewByteArrayTag(tagName, payload), nil
 	case *StringPayload:
-		return NewStringTag(&name, payload), nil
+		return NewStringTag(tagName, payload), nil
 	case *ListPayload:
-		return NewListTag(&name, payload), nil
+		return NewListTag(tagName, payload), nil
 	case *CompoundPayload:
-		return NewCompoundTag(&name, payload), nil
+		return NewCompoundTag(tagName, payload), nil
 	case *IntArrayPayload:
-		return NewIntArrayTag(&name, payload), nil
+		return NewIntArrayTag(tagName, payload), nil
 	case *LongArrayPayload:
-		return NewLongArrayTag(&name, payload), nil
+		return NewLongArrayTag(tagName, payload), nil
 	default:
-		err = &NbtError{Op: "new", Err: ErrInvalidSnbtFormat}
-		logger.Println("failed to new", "func", getFuncName(), "error", err)
+		err := &NbtError{Op: "new", Err: ErrInvalidTagType}
+		logError(nil, "failed to new", "error", err)
 		return nil, err
 	}
 }
 
 func Encode(w io.Writer, tag Tag) error {
+	return EncodeWithOptions(w, tag, new(EncodeOptions))
+}
+
+func EncodeWithOptions(w io.Writer, tag Tag, opts *EncodeOptions) error {
+	if err := encodeTag(w, tag, opts); err != nil {
+		logError(opts.logger(), "failed to encode", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func encodeTag(w io.Writer, tag Tag, opts *EncodeOptions) error {
 	typ := tag.TypeId()
-	if err := typ.encode(w); err != nil {
-		logger.Println("failed to encode", "func", getFuncName(), "error", err)
+	if err := typ.encode(w, opts); err != nil {
+		logError(opts.logger(), "failed to encode", "error", err)
 		return err
 	}
 
@@ -135,30 +166,133 @@ func Encode(w io.Writer, tag Tag) error {
 		return nil
 	}
 
-	if err := tag.TagName().encode(w); err != nil {
-		logger.Println("failed to encode", "func", getFuncName(), "error", err)
+	if err := tag.TagName().encode(w, opts); err != nil {
+		logError(opts.logger(), "failed to encode", "error", err)
 		return err
 	}
 
-	if err := tag.Payload().encode(w); err != nil {
-		logger.Println("failed to encode", "func", getFuncName(), "error", err)
+	if err := tag.Payload().EncodeNBT(w, opts); err != nil {
+		logError(opts.logger(), "failed to encode", "error", err)
 		return err
 	}
 
 	return nil
 }
 
+func EncodedSize(tag Tag) int {
+	return EncodedSizeWithOptions(tag, new(EncodeOptions))
+}
+
+func EncodedSizeWithOptions(tag Tag, opts *EncodeOptions) int {
+	return tagSize(tag, opts)
+}
+
+func AppendEncode(dst []byte, tag Tag) ([]byte, error) {
+	return AppendEncodeWithOptions(dst, tag, new(EncodeOptions))
+}
+
+func AppendEncodeWithOptions(dst []byte, tag Tag, opts *EncodeOptions) ([]byte, error) {
+	dst, err := appendTag(dst, tag, opts)
+	if err != nil {
+		logError(opts.logger(), "failed to encode", "error", err)
+		return nil, err
+	}
+
+	return dst, nil
+}
+
+func appendTag(dst []byte, tag Tag, opts *EncodeOptions) ([]byte, error) {
+	typ := tag.TypeId()
+	dst = appendTagType(dst, typ)
+
+	if typ == TagTypeEnd {
+		return dst, nil
+	}
+
+	dst = appendString(dst, string(*tag.TagName()))
+
+	dst, err := appendNBT(dst, tag.Payload(), opts)
+	if err != nil {
+		logError(opts.logger(), "failed to encode", "error", err)
+		return nil, err
+	}
+
+	return dst, nil
+}
+
+func tagSize(tag Tag, opts *EncodeOptions) int {
+	if tag.TypeId() == TagTypeEnd {
+		return 1
+	}
+
+	return 1 + stringSize(string(*tag.TagName())) + encodedSize(tag.Payload(), opts)
+}
+
+// NOTE: falls back to EncodeNBT for tags and payloads implemented outside this package
+func appendNBT(dst []byte, v NbtEncoder, opts *EncodeOptions) ([]byte, error) {
+	if a, ok := v.(NbtAppender); ok {
+		return a.AppendNBT(dst, opts)
+	}
+
+	buf := bytes.NewBuffer(dst)
+	if err := v.EncodeNBT(buf, opts); err != nil {
+		logError(opts.logger(), "failed to encode", "error", err)
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func encodedSize(v NbtEncoder, opts *EncodeOptions) int {
+	if s, ok := v.(NbtSizer); ok {
+		return s.EncodedSize(opts)
+	}
+
+	w := new(countWriter)
+	if err := v.EncodeNBT(w, opts); err != nil {
+		logError(opts.logger(), "failed to encode", "error", err)
+	}
+
+	return w.n
+}
+
+type countWriter struct {
+	n int
+}
+
+func (w *countWriter) Write(p []byte) (int, error) {
+	w.n += len(p)
+	return len(p), nil
+}
+
 func Decode(r io.Reader) (Tag, error) {
+	return DecodeWithOptions(r, new(DecodeOptions))
+}
+
+// NOTE: r is read unbuffered up to the end of the tag, wrap r in a bufio.Reader or use a Decoder when reading from a file or a network connection
+func DecodeWithOptions(r io.Reader, opts *DecodeOptions) (Tag, error) {
+	tag, err := decodeTag(r, opts, false)
+	if err != nil {
+		logError(opts.logger(), "failed to decode", "error", err)
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func decodeTag(r io.Reader, opts *DecodeOptions, lazy bool) (Tag, error) {
+	br := asByteReader(r, opts.logger())
+
 	var typ TagType
-	if err := typ.decode(r); err != nil {
-		logger.Println("failed to decode", "func", getFuncName(), "error", err)
+	if err := typ.decode(br); err != nil {
+		logError(opts.logger(), "failed to decode", "error", err)
 		return nil, err
 	}
 
-	tag, err := NewTag(typ)
+	tag, err := newLazyTag(typ, lazy)
 	if err != nil {
 		err = &NbtError{Op: "decode", Err: err}
-		logger.Println("failed to decode", "func", getFuncName(), "error", err)
+		logError(opts.logger(), "failed to decode", "error", err)
 		return nil, err
 	}
 
@@ -166,88 +300,137 @@ func Decode(r io.Reader) (Tag, error) {
 		return tag, nil
 	}
 
-	if err := tag.TagName().decode(r); err != nil {
-		logger.Println("failed to decode", "func", getFuncName(), "error", err)
-		return nil, err
+	if err := tag.TagName().decode(br); err != nil {
+		logError(opts.logger(), "failed to decode", "error", err)
+		return nil, unexpectedEOF(err)
 	}
 
-	if err := tag.Payload().decode(r); err != nil {
-		logger.Println("failed to decode", "func", getFuncName(), "error", err)
-		return nil, err
+	if logEnabled(opts.logger(), slog.LevelDebug) {
+		logDebug(opts.logger(), "decoding tag", "type", typ.String(), "name", string(*tag.TagName()), "lazy", lazy)
+	}
+
+	if err := tag.Payload().DecodeNBT(br, opts); err != nil {
+		logError(opts.logger(), "failed to decode", "error", err)
+		return nil, unexpectedEOF(err)
 	}
 
 	return tag, nil
 }
 
 func Stringify(tag Tag) string {
-	return prettyStringify(tag, " ", "")
+	return StringifyWithOptions(tag, &StringifyOptions{Space: " "})
 }
 
 func CompactStringify(tag Tag) string {
-	return prettyStringify(tag, "", "")
+	return StringifyWithOptions(tag, &StringifyOptions{})
 }
 
 func PrettyStringify(tag Tag, indent string) string {
-	return prettyStringify(tag, " ", indent)
+	return StringifyWithOptions(tag, &StringifyOptions{Space: " ", Indent: indent})
 }
 
-func prettyStringify(tag Tag, space string, indent string) string {
-	rootName := ""
-	if tag.TagName() != nil {
-		rootName = string(*tag.TagName())
-	}
-
-	if rootName == "" {
-		snbt := tag.stringify(space, indent, 0)
-		return strings.TrimLeft(snbt[1:], space)
-	}
-
-	if indent == "" {
-		return fmt.Sprintf("{%s%s}", indent, tag.stringify(space, indent, 1))
-	}
+func StringifyWithOptions(tag Tag, opts *StringifyOptions) string {
+	sb := new(strings.Builder)
+	_ = WriteSNBT(sb, tag, opts)
 
-	return fmt.Sprintf("{\n%s%s\n}", indent, tag.stringify(space, indent, 1))
+	return sb.String()
 }
 
-func stringifyTag(tag Tag, space string, indent string, depth int) string {
-	if tag.TypeId() == TagTypeEnd {
-		return ""
+func stringifyTag(dst []byte, tag Tag, opts *StringifyOptions, depth int) []byte {
+	if opts == nil {
+		opts = new(StringifyOptions)
 	}
 
-	return fmt.Sprintf("%s:%s%s", tag.TagName().stringify(), space, tag.Payload().stringify(space, indent, depth))
+	sw := &snbtWriter{buf: dst, opts: opts}
+	sw.tag(tag, depth)
+
+	return sw.buf
 }
 
 func Parse(stringified string) (Tag, error) {
-	p := snbt.NewParser(stringified)
+	return ParseWithOptions(stringified, new(ParseOptions))
+}
+
+func ParseWithOptions(stringified string, opts *ParseOptions) (Tag, error) {
+	if opts == nil {
+		opts = new(ParseOptions)
+	}
+
+	p := snbt.NewParserWithOptions(stringified, &snbt.ParserOptions{Logger: opts.logger(), Lenient: opts.Lenient})
 
 	if err := p.Compact(); err != nil {
 		err = &NbtError{Op: "parse", Err: err}
-		logger.Println("failed to parse", "func", getFuncName(), "error", err)
+		logError(opts.logger(), "failed to parse", "error", err)
 		return nil, err
 	}
 
 	tag, err := newTagFromSnbt(p)
 	if err != nil {
-		err = &NbtError{Op: "parse", Err: err}
-		logger.Println("failed to parse", "func", getFuncName(), "error", err)
+		err = &NbtError{Op: "parse", Err: locate(p, err)}
+		logError(opts.logger(), "failed to parse", "error", err)
 		return nil, err
 	}
 
+	if logEnabled(opts.logger(), slog.LevelDebug) {
+		logDebug(opts.logger(), "parsing tag", "type", tag.TypeId().String(), "length", len(stringified))
+	}
+
 	if err := tag.parse(p); err != nil {
-		logger.Println("failed to parse", "func", getFuncName(), "error", err)
+		err = &NbtError{Op: "parse", Err: locate(p, err)}
+		logError(opts.logger(), "failed to parse", "error", err)
 		return nil, err
 	}
 
 	return tag, nil
 }
 
+// NOTE: places err at the start of the value the parser stopped in, unless it already carries a position, running out of tokens is placed at the end of input
+func locate(parser *snbt.Parser, err error) error {
+	if errors.Is(err, snbt.ErrStopIteration) {
+		return parser.ErrorAt(parser.CurrToken().Index(), "parse", snbt.ErrUnexpectedEnd)
+	}
+
+	var e *snbt.SnbtError
+	if errors.As(err, &e) && e.Line > 0 {
+		return e
+	}
+
+	var s *syntaxError
+	if errors.As(err, &s) {
+		err = s
+	}
+
+	// NOTE: inner wrappers are dropped, so that the message has a single prefix
+	for {
+		switch e := err.(type) {
+		case *NbtError:
+			err = e.Err
+			continue
+		case *snbt.SnbtError:
+			err = e.Err
+			continue
+		}
+
+		break
+	}
+
+	return parser.ErrorAt(parser.PrevToken().Index()+1, "parse", err)
+}
+
 func parseTag(tag Tag, parser *snbt.Parser) error {
 	if tag.TypeId() == TagTypeEnd {
 		return nil
 	}
 
-	if err := tag.Payload().parse(parser); err != nil {
-		logger.Println("failed to parse", "func", getFuncName(), "error", err)
+	payload, ok := tag.Payload().(snbtPayload)
+	if !ok {
+		err := &NbtError{Op: "parse", Err: ErrInvalidSnbtFormat}
+		logError(parser.Logger(), "failed to parse", "error", err)
+		return err
+	}
+
+	if err := payload.parse(parser); err != nil {
+		logError(parser.Logger(), "failed to parse", "error", err)
 		return err
 	}
 
@@ -267,27 +450,59 @@ func PrettyJson(tag Tag, indent string) string {
 }
 
 func prettyJson(tag Tag, space string, indent string) string {
+	json, _ := JsonWithOptions(tag, &JsonOptions{Space: space, Indent: indent})
+	return json
+}
+
+func JsonWithOptions(tag Tag, opts *JsonOptions) (string, error) {
+	if opts == nil {
+		opts = new(JsonOptions)
+	}
+
+	o := *opts
+	o.err = nil
+
+	json := rootJson(tag, &o)
+	if o.err != nil {
+		logError(o.Logger, "failed to json", "error", o.err)
+		return "", o.err
+	}
+
+	return json, nil
+}
+
+func rootJson(tag Tag, opts *JsonOptions) string {
+	space, indent := opts.Space, opts.Indent
+
 	rootName := ""
 	if tag.TagName() != nil {
 		rootName = string(*tag.TagName())
 	}
 
 	if rootName == "" {
-		snbt := tag.json(space, indent, 0)
-		return strings.TrimLeft(snbt[3:], space)
+		json := string(tag.AppendJSON(nil, opts, 0))
+		return strings.TrimLeft(json[3:], space)
 	}
 
 	if indent == "" {
-		return fmt.Sprintf("{%s%s}", indent, tag.json(space, indent, 1))
+		return fmt.Sprintf("{%s%s}", indent, tag.AppendJSON(nil, opts, 1))
 	}
 
-	return fmt.Sprintf("{\n%s%s\n}", indent, tag.json(space, indent, 1))
+	return fmt.Sprintf("{\n%s%s\n}", indent, tag.AppendJSON(nil, opts, 1))
 }
 
-func jsonTag(tag Tag, space string, indent string, depth int) string {
+func jsonTag(dst []byte, tag Tag, opts *JsonOptions, depth int) []byte {
+	if opts == nil {
+		opts = new(JsonOptions)
+	}
+
 	if tag.TypeId() == TagTypeEnd {
-		return ""
+		return dst
 	}
 
-	return fmt.Sprintf("%s:%s%s", tag.TagName().json(), space, tag.Payload().json(space, indent, depth))
+	dst = append(dst, tag.TagName().json()...)
+	dst = append(dst, ':')
+	dst = append(dst, opts.Space...)
+
+	return tag.Payload().AppendJSON(dst, opts, depth)
 }
@@ -39,7 +39,7 @@ func NewCompoundTag(tagName *TagName, payload *CompoundPayload) *CompoundTag {
 }
 
 func (t CompoundTag) String() string {
-	return t.stringify(" ", "", 0)
+	return string(t.AppendSNBT(nil, &StringifyOptions{Space: " "}, 0))
 }
 
 func (t *CompoundTag) TypeId() TagType {
@@ -54,26 +54,40 @@ func (t *CompoundTag) Payload() Payload {
 	return t.payload
 }
 
-func (t *CompoundTag) encode(w io.Writer) error {
-	if err := Encode(w, t); err != nil {
-		logger.Println("failed to encode", "func", getFuncName(), "tag", t, "error", err)
+func (t *CompoundTag) EncodeNBT(w io.Writer, opts *EncodeOptions) error {
+	if err := encodeTag(w, t, opts); err != nil {
+		logError(opts.logger(), "failed to encode", "tag", t, "error", err)
 		return err
 	}
 
 	return nil
 }
 
-func (t *CompoundTag) decode(r io.Reader) error {
-	tag, err := Decode(r)
+func (t *CompoundTag) AppendNBT(dst []byte, opts *EncodeOptions) ([]byte, error) {
+	dst, err := appendTag(dst, t, opts)
 	if err != nil {
-		logger.Println("failed to decode", "func", getFuncName(), "tag", t, "error", err)
+		logError(opts.logger(), "failed to encode", "tag", t, "error", err)
+		return nil, err
+	}
+
+	return dst, nil
+}
+
+func (t *CompoundTag) EncodedSize(opts *EncodeOptions) int {
+	return tagSize(t, opts)
+}
+
+func (t *CompoundTag) DecodeNBT(r io.Reader, opts *DecodeOptions) error {
+	tag, err := decodeTag(r, opts, false)
+	if err != nil {
+		logError(opts.logger(), "failed to decode", "tag", t, "error", err)
 		return err
 	}
 
 	v, ok := tag.(*CompoundTag)
 	if !ok {
 		err = &NbtError{Op: "decode", Err: ErrDecode}
-		logger.Println("failed to decode", "func", getFuncName(), "tag", t, "error", err)
+		logError(opts.logger(), "failed to decode", "tag", t, "error", err)
 		return err
 	}
 
@@ -82,19 +96,19 @@ func (t *CompoundTag) decode(r io.Reader) error {
 	return nil
 }
 
-func (t *CompoundTag) stringify(space string, indent string, depth int) string {
-	return stringifyTag(t, space, indent, depth)
+func (t *CompoundTag) AppendSNBT(dst []byte, opts *StringifyOptions, depth int) []byte {
+	return stringifyTag(dst, t, opts, depth)
 }
 
 func (t *CompoundTag) parse(parser *snbt.Parser) error {
 	if err := parseTag(t, parser); err != nil {
-		logger.Println("failed to parse", "func", getFuncName(), "tag", t, "error", err)
+		logError(parser.Logger(), "failed to parse", "tag", t, "error", err)
 		return err
 	}
 
 	return nil
 }
 
-func (t *CompoundTag) json(space string, indent string, depth int) string {
-	return jsonTag(t, space, indent, depth)
+func (t *CompoundTag) AppendJSON(dst []byte, opts *JsonOptions, depth int) []byte {
+	return jsonTag(dst, t, opts, depth)
 }
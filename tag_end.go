@@ -34,7 +34,7 @@ func NewEndTag() *EndTag {
 }
 
 func (t EndTag) String() string {
-	return t.stringify(" ", "", 0)
+	return string(t.AppendSNBT(nil, &StringifyOptions{Space: " "}, 0))
 }
 
 func (t *EndTag) TypeId() TagType {
@@ -49,26 +49,40 @@ func (t *EndTag) Payload() Payload {
 	return nil
 }
 
-func (t *EndTag) encode(w io.Writer) error {
-	if err := Encode(w, t); err != nil {
-		logger.Println("failed to encode", "func", getFuncName(), "tag", t, "error", err)
+func (t *EndTag) EncodeNBT(w io.Writer, opts *EncodeOptions) error {
+	if err := encodeTag(w, t, opts); err != nil {
+		logError(opts.logger(), "failed to encode", "tag", t, "error", err)
 		return err
 	}
 
 	return nil
 }
 
-func (t *EndTag) decode(r io.Reader) error {
-	tag, err := Decode(r)
+func (t *EndTag) AppendNBT(dst []byte, opts *EncodeOptions) ([]byte, error) {
+	dst, err := appendTag(dst, t, opts)
 	if err != nil {
-		logger.Println("failed to decode", "func", getFuncName(), "tag", t, "error", err)
+		logError(opts.logger(), "failed to encode", "tag", t, "error", err)
+		return nil, err
+	}
+
+	return dst, nil
+}
+
+func (t *EndTag) EncodedSize(opts *EncodeOptions) int {
+	return tagSize(t, opts)
+}
+
+func (t *EndTag) DecodeNBT(r io.Reader, opts *DecodeOptions) error {
+	tag, err := decodeTag(r, opts, false)
+	if err != nil {
+		logError(opts.logger(), "failed to decode", "tag", t, "error", err)
 		return err
 	}
 
 	v, ok := tag.(*EndTag)
 	if !ok {
 		err = &NbtError{Op: "decode", Err: ErrDecode}
-		logger.Println("failed to decode", "func", getFuncName(), "tag", t, "error", err)
+		logError(opts.logger(), "failed to decode", "tag", t, "error", err)
 		return err
 	}
 
@@ -77,19 +91,19 @@ func (t *EndTag) decode(r io.Reader) error {
 	return nil
 }
 
-func (t *EndTag) stringify(space string, indent string, depth int) string {
-	return stringifyTag(t, space, indent, depth)
+func (t *EndTag) AppendSNBT(dst []byte, opts *StringifyOptions, depth int) []byte {
+	return stringifyTag(dst, t, opts, depth)
 }
 
 func (t *EndTag) parse(parser *snbt.Parser) error {
 	if err := parseTag(t, parser); err != nil {
-		logger.Println("failed to parse", "func", getFuncName(), "tag", t, "error", err)
+		logError(parser.Logger(), "failed to parse", "tag", t, "error", err)
 		return err
 	}
 
 	return nil
 }
 
-func (t *EndTag) json(space string, indent string, depth int) string {
-	return jsonTag(t, space, indent, depth)
+func (t *EndTag) AppendJSON(dst []byte, opts *JsonOptions, depth int) []byte {
+	return jsonTag(dst, t, opts, depth)
 }
@@ -59,7 +59,7 @@ func TestEndTag_TypeId(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
-func TestEndTag_encode(t *testing.T) {
+func TestEndTag_EncodeNBT(t *testing.T) {
 	type Case struct {
 		name        string
 		tag         *EndTag
@@ -81,7 +81,7 @@ func TestEndTag_encode(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			buf := new(bytes.Buffer)
-			err := tt.tag.encode(buf)
+			err := tt.tag.EncodeNBT(buf, new(EncodeOptions))
 
 			if tt.expectedErr == nil {
 				assert.NoError(t, err)
@@ -94,7 +94,67 @@ func TestEndTag_encode(t *testing.T) {
 	}
 }
 
-func TestEndTag_decode(t *testing.T) {
+func TestEndTag_AppendNBT(t *testing.T) {
+	type Case struct {
+		name        string
+		tag         *EndTag
+		expected    []byte
+		expectedErr error
+	}
+
+	cases := []Case{}
+
+	for _, c := range endTagCases {
+		cases = append(cases, Case{
+			name:        c.name,
+			tag:         NewEndTag(),
+			expected:    c.raw.tagType,
+			expectedErr: nil,
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix := []byte{0xFF}
+			actual, err := tt.tag.AppendNBT(prefix, new(EncodeOptions))
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, append(prefix, tt.expected...), actual)
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestEndTag_EncodedSize(t *testing.T) {
+	type Case struct {
+		name     string
+		tag      *EndTag
+		expected int
+	}
+
+	cases := []Case{}
+
+	for _, c := range endTagCases {
+		cases = append(cases, Case{
+			name:     c.name,
+			tag:      NewEndTag(),
+			expected: len(c.raw.tagType),
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.tag.EncodedSize(new(EncodeOptions))
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestEndTag_DecodeNBT(t *testing.T) {
 	type Case struct {
 		name        string
 		raw         []byte
@@ -118,7 +178,7 @@ func TestEndTag_decode(t *testing.T) {
 			buf := bytes.NewBuffer(tt.raw)
 
 			tag := new(EndTag)
-			err := tag.decode(buf)
+			err := tag.DecodeNBT(buf, new(DecodeOptions))
 
 			if tt.expectedErr == nil {
 				assert.NoError(t, err)
@@ -131,7 +191,7 @@ func TestEndTag_decode(t *testing.T) {
 	}
 }
 
-func TestEndTag_stringify(t *testing.T) {
+func TestEndTag_AppendSNBT(t *testing.T) {
 	type Case struct {
 		name     string
 		tag      *EndTag
@@ -150,13 +210,13 @@ func TestEndTag_stringify(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := tt.tag.stringify(" ", "", 0)
+			actual := string(tt.tag.AppendSNBT(nil, &StringifyOptions{Space: " "}, 0))
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
 }
 
-func TestEndTag_json(t *testing.T) {
+func TestEndTag_AppendJSON(t *testing.T) {
 	type Case struct {
 		name     string
 		tag      *EndTag
@@ -175,7 +235,7 @@ func TestEndTag_json(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := tt.tag.json(" ", "", 0)
+			actual := string(tt.tag.AppendJSON(nil, &JsonOptions{Space: " "}, 0))
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
@@ -68,7 +68,7 @@ func TestLongArrayTag_TypeId(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
-func TestLongArrayTag_encode(t *testing.T) {
+func TestLongArrayTag_EncodeNBT(t *testing.T) {
 	type Case struct {
 		name        string
 		tag         *LongArrayTag
@@ -90,7 +90,7 @@ func TestLongArrayTag_encode(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			buf := new(bytes.Buffer)
-			err := tt.tag.encode(buf)
+			err := tt.tag.EncodeNBT(buf, new(EncodeOptions))
 
 			if tt.expectedErr == nil {
 				assert.NoError(t, err)
@@ -103,7 +103,67 @@ func TestLongArrayTag_encode(t *testing.T) {
 	}
 }
 
-func TestLongArrayTag_decode(t *testing.T) {
+func TestLongArrayTag_AppendNBT(t *testing.T) {
+	type Case struct {
+		name        string
+		tag         *LongArrayTag
+		expected    []byte
+		expectedErr error
+	}
+
+	cases := []Case{}
+
+	for _, c := range longArrayTagCases {
+		cases = append(cases, Case{
+			name:        c.name,
+			tag:         NewLongArrayTag(&c.nbt.tagName, c.nbt.payload),
+			expected:    slices.Concat(c.raw.tagType, c.raw.tagName, c.raw.payload),
+			expectedErr: nil,
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix := []byte{0xFF}
+			actual, err := tt.tag.AppendNBT(prefix, new(EncodeOptions))
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, append(prefix, tt.expected...), actual)
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestLongArrayTag_EncodedSize(t *testing.T) {
+	type Case struct {
+		name     string
+		tag      *LongArrayTag
+		expected int
+	}
+
+	cases := []Case{}
+
+	for _, c := range longArrayTagCases {
+		cases = append(cases, Case{
+			name:     c.name,
+			tag:      NewLongArrayTag(&c.nbt.tagName, c.nbt.payload),
+			expected: len(slices.Concat(c.raw.tagType, c.raw.tagName, c.raw.payload)),
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.tag.EncodedSize(new(EncodeOptions))
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestLongArrayTag_DecodeNBT(t *testing.T) {
 	type Case struct {
 		name        string
 		raw         []byte
@@ -127,7 +187,7 @@ func TestLongArrayTag_decode(t *testing.T) {
 			buf := bytes.NewBuffer(tt.raw)
 
 			tag := new(LongArrayTag)
-			err := tag.decode(buf)
+			err := tag.DecodeNBT(buf, new(DecodeOptions))
 
 			if tt.expectedErr == nil {
 				assert.NoError(t, err)
@@ -140,7 +200,7 @@ func TestLongArrayTag_decode(t *testing.T) {
 	}
 }
 
-func TestLongArrayTag_stringify_default(t *testing.T) {
+func TestLongArrayTag_AppendSNBT_default(t *testing.T) {
 	type Case struct {
 		name     string
 		tag      *LongArrayTag
@@ -159,13 +219,13 @@ func TestLongArrayTag_stringify_default(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := tt.tag.stringify(" ", "", 0)
+			actual := string(tt.tag.AppendSNBT(nil, &StringifyOptions{Space: " "}, 0))
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
 }
 
-func TestLongArrayTag_stringify_compact(t *testing.T) {
+func TestLongArrayTag_AppendSNBT_compact(t *testing.T) {
 	type Case struct {
 		name     string
 		tag      *LongArrayTag
@@ -184,13 +244,13 @@ func TestLongArrayTag_stringify_compact(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := tt.tag.stringify("", "", 0)
+			actual := string(tt.tag.AppendSNBT(nil, &StringifyOptions{}, 0))
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
 }
 
-func TestLongArrayTag_stringify_pretty(t *testing.T) {
+func TestLongArrayTag_AppendSNBT_pretty(t *testing.T) {
 	type Case struct {
 		name     string
 		tag      *LongArrayTag
@@ -209,13 +269,13 @@ func TestLongArrayTag_stringify_pretty(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := tt.tag.stringify(" ", "  ", 0)
+			actual := string(tt.tag.AppendSNBT(nil, &StringifyOptions{Space: " ", Indent: "  "}, 0))
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
 }
 
-func TestLongArrayTag_json_default(t *testing.T) {
+func TestLongArrayTag_AppendJSON_default(t *testing.T) {
 	type Case struct {
 		name     string
 		tag      *LongArrayTag
@@ -234,13 +294,13 @@ func TestLongArrayTag_json_default(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := tt.tag.json(" ", "", 0)
+			actual := string(tt.tag.AppendJSON(nil, &JsonOptions{Space: " "}, 0))
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
 }
 
-func TestLongArrayTag_json_compact(t *testing.T) {
+func TestLongArrayTag_AppendJSON_compact(t *testing.T) {
 	type Case struct {
 		name     string
 		tag      *LongArrayTag
@@ -259,13 +319,13 @@ func TestLongArrayTag_json_compact(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := tt.tag.json("", "", 0)
+			actual := string(tt.tag.AppendJSON(nil, &JsonOptions{}, 0))
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
 }
 
-func TestLongArrayTag_json_pretty(t *testing.T) {
+func TestLongArrayTag_AppendJSON_pretty(t *testing.T) {
 	type Case struct {
 		name     string
 		tag      *LongArrayTag
@@ -284,7 +344,7 @@ func TestLongArrayTag_json_pretty(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := tt.tag.json(" ", "  ", 0)
+			actual := string(tt.tag.AppendJSON(nil, &JsonOptions{Space: " ", Indent: "  "}, 0))
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
@@ -0,0 +1,140 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"io"
+)
+
+type RawTag struct {
+	tagName *TagName
+	payload *RawPayload
+}
+
+func NewRawTag(tagName *TagName, payload *RawPayload) *RawTag {
+	return &RawTag{
+		tagName: tagName,
+		payload: payload,
+	}
+}
+
+func (t RawTag) String() string {
+	return string(t.AppendSNBT(nil, &StringifyOptions{Space: " "}, 0))
+}
+
+func (t *RawTag) TypeId() TagType {
+	return t.Payload().TypeId()
+}
+
+func (t *RawTag) TagName() *TagName {
+	return t.tagName
+}
+
+func (t *RawTag) Payload() Payload {
+	return t.payload
+}
+
+func (t *RawTag) Decode(opts *DecodeOptions) (Tag, error) {
+	payload, err := t.payload.Decode(opts)
+	if err != nil {
+		logError(opts.logger(), "failed to decode", "tag", t, "error", err)
+		return nil, err
+	}
+
+	tag, err := newTagWithPayload(t.tagName, payload)
+	if err != nil {
+		err = &NbtError{Op: "decode", Err: err}
+		logError(opts.logger(), "failed to decode", "tag", t, "error", err)
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (t *RawTag) EncodeNBT(w io.Writer, opts *EncodeOptions) error {
+	if err := encodeTag(w, t, opts); err != nil {
+		logError(opts.logger(), "failed to encode", "tag", t, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (t *RawTag) AppendNBT(dst []byte, opts *EncodeOptions) ([]byte, error) {
+	dst, err := appendTag(dst, t, opts)
+	if err != nil {
+		logError(opts.logger(), "failed to encode", "tag", t, "error", err)
+		return nil, err
+	}
+
+	return dst, nil
+}
+
+func (t *RawTag) EncodedSize(opts *EncodeOptions) int {
+	return tagSize(t, opts)
+}
+
+func (t *RawTag) DecodeNBT(r io.Reader, opts *DecodeOptions) error {
+	br := asByteReader(r, opts.logger())
+
+	var typ TagType
+	if err := typ.decode(br); err != nil {
+		logError(opts.logger(), "failed to decode", "tag", t, "error", err)
+		return err
+	}
+
+	if typ == TagTypeEnd {
+		*t = *NewRawTag(new(TagName), NewRawPayload(typ, nil))
+		return nil
+	}
+
+	var name TagName
+	if err := name.decode(br); err != nil {
+		logError(opts.logger(), "failed to decode", "tag", t, "error", err)
+		return err
+	}
+
+	payload := NewRawPayload(typ, nil)
+	if err := payload.DecodeNBT(br, opts); err != nil {
+		logError(opts.logger(), "failed to decode", "tag", t, "error", err)
+		return err
+	}
+
+	*t = *NewRawTag(&name, payload)
+
+	return nil
+}
+
+func (t *RawTag) AppendSNBT(dst []byte, opts *StringifyOptions, depth int) []byte {
+	return stringifyTag(dst, t, opts, depth)
+}
+
+func (t *RawTag) AppendJSON(dst []byte, opts *JsonOptions, depth int) []byte {
+	return jsonTag(dst, t, opts, depth)
+}
+
+func newLazyTag(typ TagType, lazy bool) (Tag, error) {
+	if lazy && lazyDecodable(typ) {
+		return NewRawTag(new(TagName), NewRawPayload(typ, nil)), nil
+	}
+
+	return NewTag(typ)
+}
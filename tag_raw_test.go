@@ -0,0 +1,235 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/Aton-Kish/gonbt/slices"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRawTag(t *testing.T) {
+	type Case struct {
+		name     string
+		tagName  *TagName
+		payload  *RawPayload
+		expected *RawTag
+	}
+
+	cases := []Case{}
+
+	for _, c := range excludeEndTagCases {
+		c := c
+		cases = append(cases, Case{
+			name:    c.name,
+			tagName: &c.nbt.tagName,
+			payload: NewRawPayload(c.nbt.tagType, c.raw.payload),
+			expected: &RawTag{
+				tagName: &c.nbt.tagName,
+				payload: NewRawPayload(c.nbt.tagType, c.raw.payload),
+			},
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := NewRawTag(tt.tagName, tt.payload)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestRawTag_Decode(t *testing.T) {
+	type Case struct {
+		name        string
+		tag         *RawTag
+		expected    Tag
+		expectedErr error
+	}
+
+	cases := []Case{}
+
+	for _, c := range excludeEndTagCases {
+		c := c
+		expected, err := newTagWithPayload(&c.nbt.tagName, c.nbt.payload)
+		assert.NoError(t, err)
+
+		cases = append(cases, Case{
+			name:        c.name,
+			tag:         NewRawTag(&c.nbt.tagName, NewRawPayload(c.nbt.tagType, c.raw.payload)),
+			expected:    expected,
+			expectedErr: nil,
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := tt.tag.Decode(new(DecodeOptions))
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, actual)
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestRawTag_EncodeNBT(t *testing.T) {
+	type Case struct {
+		name        string
+		tag         *RawTag
+		expected    []byte
+		expectedErr error
+	}
+
+	cases := []Case{}
+
+	for _, c := range excludeEndTagCases {
+		c := c
+		cases = append(cases, Case{
+			name:        c.name,
+			tag:         NewRawTag(&c.nbt.tagName, NewRawPayload(c.nbt.tagType, c.raw.payload)),
+			expected:    slices.Concat(c.raw.tagType, c.raw.tagName, c.raw.payload),
+			expectedErr: nil,
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := tt.tag.EncodeNBT(buf, new(EncodeOptions))
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, buf.Bytes())
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestRawTag_DecodeNBT(t *testing.T) {
+	type Case struct {
+		name        string
+		raw         []byte
+		expected    *RawTag
+		expectedErr error
+	}
+
+	cases := []Case{}
+
+	for _, c := range excludeEndTagCases {
+		c := c
+		cases = append(cases, Case{
+			name:        c.name,
+			raw:         slices.Concat(c.raw.tagType, c.raw.tagName, c.raw.payload),
+			expected:    NewRawTag(&c.nbt.tagName, NewRawPayload(c.nbt.tagType, c.raw.payload)),
+			expectedErr: nil,
+		})
+	}
+
+	cases = append(cases, Case{
+		name:        `positive case: End`,
+		raw:         []byte{0x00},
+		expected:    NewRawTag(new(TagName), NewRawPayload(TagTypeEnd, nil)),
+		expectedErr: nil,
+	})
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := bytes.NewBuffer(tt.raw)
+
+			tag := new(RawTag)
+			err := tag.DecodeNBT(buf, new(DecodeOptions))
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.TagName(), tag.TagName())
+				assert.Equal(t, tt.expected.TypeId(), tag.TypeId())
+				assert.Equal(t, tt.expected.payload.Bytes(), tag.payload.Bytes())
+				assert.Zero(t, buf.Len())
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestRawTag_AppendSNBT(t *testing.T) {
+	type Case struct {
+		name     string
+		tag      *RawTag
+		expected string
+	}
+
+	cases := []Case{}
+
+	for _, c := range excludeEndTagCases {
+		c := c
+		cases = append(cases, Case{
+			name:     c.name,
+			tag:      NewRawTag(&c.nbt.tagName, NewRawPayload(c.nbt.tagType, c.raw.payload)),
+			expected: fmt.Sprintf("%s: %s", c.snbt.tagName, c.snbt.payload.typeDefault),
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := string(tt.tag.AppendSNBT(nil, &StringifyOptions{Space: " "}, 0))
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestRawTag_AppendJSON(t *testing.T) {
+	type Case struct {
+		name     string
+		tag      *RawTag
+		expected string
+	}
+
+	cases := []Case{}
+
+	for _, c := range excludeEndTagCases {
+		c := c
+		cases = append(cases, Case{
+			name:     c.name,
+			tag:      NewRawTag(&c.nbt.tagName, NewRawPayload(c.nbt.tagType, c.raw.payload)),
+			expected: fmt.Sprintf("%s: %s", c.json.tagName, c.json.payload.typeDefault),
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := string(tt.tag.AppendJSON(nil, &JsonOptions{Space: " "}, 0))
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
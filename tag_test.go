@@ -22,8 +22,11 @@ package nbt
 
 import (
 	"bytes"
+	"context"
+	"io"
 	"testing"
 
+	"github.com/Aton-Kish/gonbt/snbt"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -170,6 +173,76 @@ func TestEncode(t *testing.T) {
 	}
 }
 
+func TestAppendEncode(t *testing.T) {
+	type Case struct {
+		name        string
+		nbt         Tag
+		expected    []byte
+		expectedErr error
+	}
+
+	cases := []Case{}
+
+	for _, c := range nbtCases {
+		cases = append(cases, Case{
+			name:        c.name,
+			nbt:         c.nbt,
+			expected:    c.raw,
+			expectedErr: nil,
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix := []byte{0xFF}
+			actual, err := AppendEncode(prefix, tt.nbt)
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, append(prefix, tt.expected...), actual)
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestAppendEncodeWithOptions_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	actual, err := AppendEncodeWithOptions(nil, nbtCases[0].nbt, new(EncodeOptions).WithContext(ctx))
+	assert.Nil(t, actual)
+	assert.Equal(t, &NbtError{Op: "encode", Err: context.Canceled}, err)
+}
+
+func TestEncodedSize(t *testing.T) {
+	type Case struct {
+		name     string
+		nbt      Tag
+		expected int
+	}
+
+	cases := []Case{}
+
+	for _, c := range nbtCases {
+		cases = append(cases, Case{
+			name:     c.name,
+			nbt:      c.nbt,
+			expected: len(c.raw),
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := EncodedSize(tt.nbt)
+			assert.Equal(t, tt.expected, actual)
+			assert.Equal(t, tt.expected, EncodedSizeWithOptions(tt.nbt, new(EncodeOptions)))
+		})
+	}
+}
+
 func TestDecode(t *testing.T) {
 	type Case struct {
 		name        string
@@ -205,6 +278,210 @@ func TestDecode(t *testing.T) {
 	}
 }
 
+// NOTE: hides ReadByte, so that Decode reads the input as a plain stream
+type readOnly struct {
+	r io.Reader
+}
+
+func (r *readOnly) Read(p []byte) (int, error) {
+	return r.r.Read(p)
+}
+
+func TestDecode_unbuffered(t *testing.T) {
+	raw := append(append([]byte{}, nbtCases[0].raw...), nbtCases[1].raw...)
+
+	t.Run(`positive case: byte reader stops at the end of the tag`, func(t *testing.T) {
+		r := bytes.NewReader(raw)
+
+		actual, err := Decode(r)
+		assert.NoError(t, err)
+		assert.Equal(t, nbtCases[0].nbt, actual)
+		assert.Equal(t, len(nbtCases[1].raw), r.Len())
+
+		actual, err = Decode(r)
+		assert.NoError(t, err)
+		assert.Equal(t, nbtCases[1].nbt, actual)
+	})
+
+	t.Run(`positive case: plain reader stops at the end of the tag`, func(t *testing.T) {
+		r := &readOnly{r: bytes.NewReader(append(append([]byte{}, raw...), "trailer"...))}
+
+		actual, err := Decode(r)
+		assert.NoError(t, err)
+		assert.Equal(t, nbtCases[0].nbt, actual)
+
+		actual, err = Decode(r)
+		assert.NoError(t, err)
+		assert.Equal(t, nbtCases[1].nbt, actual)
+
+		rest, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("trailer"), rest)
+	})
+
+	t.Run(`positive case: plain reader`, func(t *testing.T) {
+		for _, c := range nbtCases {
+			actual, err := Decode(&readOnly{r: bytes.NewReader(c.raw)})
+			assert.NoError(t, err)
+			assert.Equal(t, c.nbt, actual)
+		}
+	})
+
+	t.Run(`negative case: plain reader truncated`, func(t *testing.T) {
+		actual, err := Decode(&readOnly{r: bytes.NewReader(nbtCases[1].raw[:len(nbtCases[1].raw)-2])})
+		assert.Nil(t, actual)
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+}
+
+func TestDecode_hugeLength(t *testing.T) {
+	cases := []struct {
+		name string
+		raw  []byte
+	}{
+		{name: `negative case: List`, raw: []byte{0x0A, 0x00, 0x00, 0x09, 0x00, 0x01, 'l', 0x03, 0x7F, 0xFF, 0xFF, 0xFF}},
+		{name: `negative case: ByteArray`, raw: []byte{0x0A, 0x00, 0x00, 0x07, 0x00, 0x01, 'a', 0x7F, 0xFF, 0xFF, 0xFF, 0x01}},
+		{name: `negative case: IntArray`, raw: []byte{0x0A, 0x00, 0x00, 0x0B, 0x00, 0x01, 'a', 0x7F, 0xFF, 0xFF, 0xFF, 0x01}},
+		{name: `negative case: LongArray`, raw: []byte{0x0A, 0x00, 0x00, 0x0C, 0x00, 0x01, 'a', 0x7F, 0xFF, 0xFF, 0xFF, 0x01}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// NOTE: a plain reader cannot tell how much input is left, so the declared length is not checked up front
+			actual, err := Decode(&readOnly{r: bytes.NewReader(tt.raw)})
+			assert.Nil(t, actual)
+			assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+			actual, err = DecodeSelect(&readOnly{r: bytes.NewReader(tt.raw)}, "l", "a")
+			assert.Nil(t, actual)
+			assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+			actual, err = DecodeWithOptions(&readOnly{r: bytes.NewReader(tt.raw)}, &DecodeOptions{Arrays: NewArrayPool()})
+			assert.Nil(t, actual)
+			assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		})
+	}
+}
+
+func TestDecode_longStreamArray(t *testing.T) {
+	// NOTE: longer than maxPrealloc, so that the array grows while it is read
+	values := make([]int32, 5*maxPrealloc+3)
+	for i := range values {
+		values[i] = int32(i)
+	}
+
+	expected := NewCompoundTag(NewTagName(``), NewCompoundPayload(
+		NewIntArrayTag(NewTagName(`a`), NewIntArrayPayload(values...)),
+		NewListTag(NewTagName(`l`), ListOf(make([]int8, 3*maxPrealloc)...)),
+		NewEndTag(),
+	))
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, Encode(buf, expected))
+
+	actual, err := Decode(&readOnly{r: bytes.NewReader(buf.Bytes())})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestDecodeWithOptions_lazy(t *testing.T) {
+	type Case struct {
+		name        string
+		raw         []byte
+		expected    Tag
+		expectedErr error
+	}
+
+	cases := []Case{}
+
+	for _, c := range nbtCases {
+		cases = append(cases, Case{
+			name:        c.name,
+			raw:         c.raw,
+			expected:    c.nbt,
+			expectedErr: nil,
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := bytes.NewBuffer(tt.raw)
+			actual, err := DecodeWithOptions(buf, &DecodeOptions{Lazy: true})
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.IsType(t, &CompoundTag{}, actual)
+
+				for _, tag := range *actual.Payload().(*CompoundPayload) {
+					if lazyDecodable(tag.TypeId()) {
+						assert.IsType(t, &RawTag{}, tag)
+					}
+				}
+
+				assert.Equal(t, Stringify(tt.expected), Stringify(actual))
+
+				encoded := new(bytes.Buffer)
+				err := Encode(encoded, actual)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.raw, encoded.Bytes())
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestDecode_roundTrip(t *testing.T) {
+	cases := []struct {
+		name string
+		raw  []byte
+	}{
+		{
+			name: `positive case: empty Compound list`,
+			raw: []byte{
+				0x0A, 0x00, 0x00,
+				0x09, 0x00, 0x08, 0x45, 0x6E, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73,
+				0x0A, 0x00, 0x00, 0x00, 0x00,
+				0x00,
+			},
+		},
+		{
+			name: `positive case: modified UTF-8 string`,
+			raw: []byte{
+				0x08, 0x00, 0x00,
+				// NOTE: U+0000 as C0 80 and U+1F600 as a surrogate pair
+				0x00, 0x08,
+				0xC0, 0x80,
+				0xED, 0xA0, 0xBD, 0xED, 0xB8, 0x80,
+			},
+		},
+		{
+			name: `positive case: NaN payload`,
+			raw: []byte{
+				0x05, 0x00, 0x00,
+				0x7F, 0xC0, 0x12, 0x34,
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			tag, err := Decode(bytes.NewBuffer(tt.raw))
+			assert.NoError(t, err)
+
+			buf := new(bytes.Buffer)
+			err = Encode(buf, tag)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.raw, buf.Bytes())
+
+			actual, err := AppendEncode(nil, tag)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.raw, actual)
+		})
+	}
+}
+
 func TestStringify(t *testing.T) {
 	type Case struct {
 		name     string
@@ -382,6 +659,312 @@ func TestParse_pretty(t *testing.T) {
 	}
 }
 
+func TestParse_position(t *testing.T) {
+	cases := []struct {
+		name            string
+		snbt            string
+		expectedMessage string
+		expectedLine    int
+		expectedColumn  int
+		expectedOffset  int
+		expectedExcerpt string
+	}{
+		{
+			name:            `negative case: mismatched array element`,
+			snbt:            `{a: [B; 1b, 2s]}`,
+			expectedMessage: `expected Byte, found "2s"`,
+			expectedLine:    1,
+			expectedColumn:  13,
+			expectedOffset:  12,
+			expectedExcerpt: "{a: [B; 1b, 2s]}\n            ^",
+		},
+		{
+			name:            `negative case: pretty`,
+			snbt:            "{\n  a: 1b,\n  b: [I; 1, 2b]\n}",
+			expectedMessage: `expected Int, found "2b"`,
+			expectedLine:    3,
+			expectedColumn:  13,
+			expectedOffset:  23,
+			expectedExcerpt: "  b: [I; 1, 2b]\n            ^",
+		},
+		{
+			name:            `negative case: missing value`,
+			snbt:            `{a:}`,
+			expectedMessage: `expected value, found '}'`,
+			expectedLine:    1,
+			expectedColumn:  4,
+			expectedOffset:  3,
+			expectedExcerpt: "{a:}\n   ^",
+		},
+		{
+			name:            `negative case: unterminated list`,
+			snbt:            `[1b,`,
+			expectedMessage: `unexpected end of input`,
+			expectedLine:    1,
+			expectedColumn:  5,
+			expectedOffset:  4,
+			expectedExcerpt: "[1b,\n    ^",
+		},
+		{
+			name:            `negative case: unterminated compound`,
+			snbt:            `{a: {b: 1b}`,
+			expectedMessage: `unexpected end of input`,
+			expectedLine:    1,
+			expectedColumn:  12,
+			expectedOffset:  11,
+			expectedExcerpt: "{a: {b: 1b}\n           ^",
+		},
+		{
+			name:            `negative case: unterminated string`,
+			snbt:            "{\n  id: \"minecraft:stone\n}",
+			expectedMessage: `unterminated string`,
+			expectedLine:    2,
+			expectedColumn:  7,
+			expectedOffset:  8,
+			expectedExcerpt: "  id: \"minecraft:stone\n      ^",
+		},
+		{
+			name:            `negative case: missing colon`,
+			snbt:            "{\n  Count 1b,\n  id: \"x\"\n}",
+			expectedMessage: `expected ':' after key "Count"`,
+			expectedLine:    2,
+			expectedColumn:  9,
+			expectedOffset:  10,
+			expectedExcerpt: "  Count 1b,\n        ^",
+		},
+		{
+			name:            `negative case: missing key`,
+			snbt:            `{a: 1b, , b: 2b}`,
+			expectedMessage: `expected key, found ','`,
+			expectedLine:    1,
+			expectedColumn:  9,
+			expectedOffset:  8,
+			expectedExcerpt: "{a: 1b, , b: 2b}\n        ^",
+		},
+		{
+			name:            `negative case: missing comma`,
+			snbt:            `{a: 1b b: 2b}`,
+			expectedMessage: `expected ',' after value "1b"`,
+			expectedLine:    1,
+			expectedColumn:  8,
+			expectedOffset:  7,
+			expectedExcerpt: "{a: 1b b: 2b}\n       ^",
+		},
+		{
+			name:            `negative case: mismatched closing`,
+			snbt:            `{a: [1b, 2b}}`,
+			expectedMessage: `expected ',' or ']', found '}'`,
+			expectedLine:    1,
+			expectedColumn:  12,
+			expectedOffset:  11,
+			expectedExcerpt: "{a: [1b, 2b}}\n           ^",
+		},
+		{
+			name:            `negative case: missing array separator`,
+			snbt:            `[L 1L]`,
+			expectedMessage: `expected ';' after "[L"`,
+			expectedLine:    1,
+			expectedColumn:  4,
+			expectedOffset:  3,
+			expectedExcerpt: "[L 1L]\n   ^",
+		},
+		{
+			name:            `negative case: out of range`,
+			snbt:            `{a: 2147483648}`,
+			expectedMessage: `2147483648: out of range`,
+			expectedLine:    1,
+			expectedColumn:  5,
+			expectedOffset:  4,
+			expectedExcerpt: "{a: 2147483648}\n    ^",
+		},
+		{
+			name:            `negative case: invalid escape in value`,
+			snbt:            `{a: "x\q"}`,
+			expectedMessage: `expected String, found "\"x\\q\""`,
+			expectedLine:    1,
+			expectedColumn:  5,
+			expectedOffset:  4,
+			expectedExcerpt: "{a: \"x\\q\"}\n    ^",
+		},
+		{
+			name:            `negative case: invalid escape in key`,
+			snbt:            `{"a\q": 1}`,
+			expectedMessage: `expected key, found "\"a\\q\""`,
+			expectedLine:    1,
+			expectedColumn:  2,
+			expectedOffset:  1,
+			expectedExcerpt: "{\"a\\q\": 1}\n ^",
+		},
+		{
+			name:            `negative case: text after a quoted value`,
+			snbt:            `{a: 'x'y}`,
+			expectedMessage: `expected String, found "'x'y"`,
+			expectedLine:    1,
+			expectedColumn:  5,
+			expectedOffset:  4,
+			expectedExcerpt: "{a: 'x'y}\n    ^",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := Parse(tt.snbt)
+			assert.Nil(t, actual)
+
+			var e *snbt.SnbtError
+			if assert.ErrorAs(t, err, &e) {
+				assert.Equal(t, tt.expectedMessage, e.Err.Error())
+				assert.Equal(t, tt.expectedLine, e.Line)
+				assert.Equal(t, tt.expectedColumn, e.Column)
+				assert.Equal(t, tt.expectedOffset, e.Offset)
+				assert.Equal(t, tt.expectedExcerpt, e.Excerpt)
+				assert.Contains(t, err.Error(), tt.expectedExcerpt)
+			}
+		})
+	}
+}
+
+func TestParse_syntaxError(t *testing.T) {
+	_, err := Parse(`{a: [I; 1, x]}`)
+	assert.ErrorIs(t, err, ErrInvalidSnbtFormat)
+	assert.Equal(t, "nbt parse: snbt parse: expected Int, found \"x\" at line 1, column 12\n{a: [I; 1, x]}\n           ^", err.Error())
+
+	_, err = Parse(`{a: 2147483648}`)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	assert.Equal(t, "nbt parse: snbt parse: 2147483648: out of range at line 1, column 5\n{a: 2147483648}\n    ^", err.Error())
+
+	_, err = Parse(`[1b,`)
+	assert.ErrorIs(t, err, snbt.ErrUnexpectedEnd)
+	assert.NotErrorIs(t, err, snbt.ErrStopIteration)
+}
+
+func TestParseWithOptions_lenient(t *testing.T) {
+	for _, c := range nbtCases {
+		for _, snbt := range []string{c.snbt.typeDefault, c.snbt.typeCompact, c.snbt.typePretty} {
+			t.Run(c.name, func(t *testing.T) {
+				expected, err := Parse(snbt)
+				assert.NoError(t, err)
+
+				actual, err := ParseWithOptions(snbt, &ParseOptions{Lenient: true})
+				assert.NoError(t, err)
+				assert.Equal(t, expected, actual)
+			})
+		}
+	}
+
+	cases := []struct {
+		name     string
+		snbt     string
+		strict   bool
+		expected string
+	}{
+		{
+			name: `positive case: comments and trailing commas`,
+			snbt: `{
+  // spawn template
+  id: "minecraft:pig", /* passive */
+  Tags: ["a", "b",],
+  Pos: [0.5d, 64d, 0.5d,],
+}`,
+			expected: `{Pos:[0.5d,64d,0.5d],Tags:["a","b"],id:"minecraft:pig"}`,
+		},
+		{
+			name:     `positive case: unicode keys`,
+			snbt:     `{名前: "ブタ", größe: 1b}`,
+			strict:   true,
+			expected: `{größe:1b,名前:"ブタ"}`,
+		},
+		{
+			name: `positive case: unicode line comment`,
+			snbt: `{名前: "ブタ", // 名前
+}`,
+			expected: `{名前:"ブタ"}`,
+		},
+		{
+			name:     `positive case: unicode block comment`,
+			snbt:     `{/* größe */ größe: 1b}`,
+			expected: `{größe:1b}`,
+		},
+		{
+			name:     `positive case: comment markers in string`,
+			snbt:     `{url: "https://example.com/*"}`,
+			strict:   true,
+			expected: `{url:"https://example.com/*"}`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// NOTE: without Lenient, comments are either rejected or read as part of a key or value
+			strict, err := Parse(tt.snbt)
+			if tt.strict {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, CompactStringify(strict))
+			} else if err == nil {
+				assert.NotEqual(t, tt.expected, CompactStringify(strict))
+			}
+
+			actual, err := ParseWithOptions(tt.snbt, &ParseOptions{Lenient: true})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, CompactStringify(actual))
+		})
+	}
+}
+
+func TestParseWithOptions_lenientError(t *testing.T) {
+	cases := []struct {
+		name     string
+		snbt     string
+		expected *snbt.SnbtError
+	}{
+		{
+			name: `negative case: unterminated block comment`,
+			snbt: "{\n  id: \"minecraft:pig\", /* passive\n}",
+			expected: &snbt.SnbtError{
+				Op:      "compact",
+				Err:     snbt.ErrUnterminatedComment,
+				Line:    2,
+				Column:  24,
+				Offset:  25,
+				Excerpt: "  id: \"minecraft:pig\", /* passive\n                       ^",
+			},
+		},
+		{
+			name: `negative case: unterminated block comment at the end`,
+			snbt: `{a: 1b}/*`,
+			expected: &snbt.SnbtError{
+				Op:      "compact",
+				Err:     snbt.ErrUnterminatedComment,
+				Line:    1,
+				Column:  8,
+				Offset:  7,
+				Excerpt: "{a: 1b}/*\n       ^",
+			},
+		},
+		{
+			name: `negative case: unterminated string after a comment`,
+			snbt: `{/* a */ a: "x}`,
+			expected: &snbt.SnbtError{
+				Op:      "compact",
+				Err:     snbt.ErrUnterminatedString,
+				Line:    1,
+				Column:  13,
+				Offset:  12,
+				Excerpt: "{/* a */ a: \"x}\n            ^",
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := ParseWithOptions(tt.snbt, &ParseOptions{Lenient: true})
+			assert.Nil(t, actual)
+			assert.Equal(t, &NbtError{Op: "parse", Err: tt.expected}, err)
+			assert.NotErrorIs(t, err, snbt.ErrStopIteration)
+		})
+	}
+}
+
 func TestJson(t *testing.T) {
 	type Case struct {
 		name     string
@@ -456,3 +1039,75 @@ func TestPrettyJson(t *testing.T) {
 		})
 	}
 }
+
+func TestNilOptions(t *testing.T) {
+	for _, c := range nbtCases {
+		t.Run(c.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := EncodeWithOptions(buf, c.nbt, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, c.raw, buf.Bytes())
+
+			appended, err := AppendEncodeWithOptions(nil, c.nbt, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, c.raw, appended)
+			assert.Equal(t, len(c.raw), EncodedSizeWithOptions(c.nbt, nil))
+
+			buf.Reset()
+			err = c.nbt.Payload().EncodeNBT(buf, nil)
+			assert.NoError(t, err)
+
+			actual, err := DecodeWithOptions(bytes.NewReader(c.raw), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, c.nbt, actual)
+
+			actual, n, err := DecodeBytesWithOptions(c.raw, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, c.nbt, actual)
+			assert.Equal(t, len(c.raw), n)
+
+			payload, err := NewPayload(c.nbt.TypeId())
+			assert.NoError(t, err)
+			err = payload.DecodeNBT(buf, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, c.nbt.Payload(), payload)
+
+			assert.Equal(t, c.snbt.typeCompact, StringifyWithOptions(c.nbt, nil))
+
+			json, err := JsonWithOptions(c.nbt, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, c.json.typeCompact, json)
+
+			actual, err = ParseWithOptions(c.snbt.typeCompact, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, Stringify(c.nbt), Stringify(actual))
+
+			appended, err = c.nbt.(NbtAppender).AppendNBT(nil, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, c.raw, appended)
+			assert.Equal(t, len(c.raw), c.nbt.(NbtSizer).EncodedSize(nil))
+			assert.Equal(t, string(c.nbt.AppendSNBT(nil, new(StringifyOptions), 0)), string(c.nbt.AppendSNBT(nil, nil, 0)))
+			assert.Equal(t, string(c.nbt.Payload().AppendSNBT(nil, new(StringifyOptions), 0)), string(c.nbt.Payload().AppendSNBT(nil, nil, 0)))
+			assert.Equal(t, string(c.nbt.AppendJSON(nil, new(JsonOptions), 0)), string(c.nbt.AppendJSON(nil, nil, 0)))
+			assert.Equal(t, string(c.nbt.Payload().AppendJSON(nil, new(JsonOptions), 0)), string(c.nbt.Payload().AppendJSON(nil, nil, 0)))
+
+			tags, err := DecodeAllWithOptions(bytes.NewReader(c.raw), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, []Tag{c.nbt}, tags)
+
+			actual, err = DecodeSelectWithOptions(bytes.NewReader(c.raw), nil)
+			assert.NoError(t, err)
+			selected, err := DecodeSelect(bytes.NewReader(c.raw))
+			assert.NoError(t, err)
+			assert.Equal(t, selected, actual)
+		})
+	}
+
+	t.Run(`positive case: Writer`, func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		w := NewWriterWithOptions(buf, nil)
+		assert.NoError(t, w.Int(`Count`, 1))
+		assert.NoError(t, w.Close())
+		assert.Equal(t, []byte{0x03, 0x00, 0x05, 'C', 'o', 'u', 'n', 't', 0x00, 0x00, 0x00, 0x01}, buf.Bytes())
+	})
+}
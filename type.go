@@ -21,7 +21,6 @@
 package nbt
 
 import (
-	"encoding/binary"
 	"io"
 )
 
@@ -92,10 +91,9 @@ func (t TagType) String() string {
 	}
 }
 
-func (t *TagType) encode(w io.Writer) error {
-	if err := binary.Write(w, binary.BigEndian, t); err != nil {
-		err = &NbtError{Op: "encode", Err: err}
-		logger.Println("failed to encode", "func", getFuncName(), "type", t, "error", err)
+func (t *TagType) encode(w io.Writer, opts *EncodeOptions) error {
+	if err := writeTagType(w, *t); err != nil {
+		logError(opts.logger(), "failed to encode", "type", t, "error", err)
 		return err
 	}
 
@@ -103,11 +101,15 @@ func (t *TagType) encode(w io.Writer) error {
 }
 
 func (t *TagType) decode(r io.Reader) error {
-	if err := binary.Read(r, binary.BigEndian, t); err != nil {
-		err = &NbtError{Op: "decode", Err: err}
-		logger.Println("failed to decode", "func", getFuncName(), "type", t, "error", err)
+	br := asByteReader(r, nil)
+
+	typ, err := readTagType(br)
+	if err != nil {
+		logError(br.logger, "failed to decode", "type", t, "error", err)
 		return err
 	}
 
+	*t = typ
+
 	return nil
 }
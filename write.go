@@ -0,0 +1,155 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+func appendTagType(dst []byte, typ TagType) []byte {
+	return append(dst, byte(typ))
+}
+
+func appendInt8(dst []byte, v int8) []byte {
+	return append(dst, byte(v))
+}
+
+func appendInt16(dst []byte, v int16) []byte {
+	return binary.BigEndian.AppendUint16(dst, uint16(v))
+}
+
+func appendInt32(dst []byte, v int32) []byte {
+	return binary.BigEndian.AppendUint32(dst, uint32(v))
+}
+
+func appendInt64(dst []byte, v int64) []byte {
+	return binary.BigEndian.AppendUint64(dst, uint64(v))
+}
+
+func appendFloat32(dst []byte, v float32) []byte {
+	return binary.BigEndian.AppendUint32(dst, math.Float32bits(v))
+}
+
+func appendFloat64(dst []byte, v float64) []byte {
+	return binary.BigEndian.AppendUint64(dst, math.Float64bits(v))
+}
+
+func appendString(dst []byte, s string) []byte {
+	dst = binary.BigEndian.AppendUint16(dst, uint16(len(s)))
+	return append(dst, s...)
+}
+
+func appendLength(dst []byte, l int) []byte {
+	return appendInt32(dst, int32(l))
+}
+
+func appendInt8s(dst []byte, values []int8) []byte {
+	for _, v := range values {
+		dst = append(dst, byte(v))
+	}
+
+	return dst
+}
+
+func appendInt32s(dst []byte, values []int32) []byte {
+	for _, v := range values {
+		dst = binary.BigEndian.AppendUint32(dst, uint32(v))
+	}
+
+	return dst
+}
+
+func appendInt64s(dst []byte, values []int64) []byte {
+	for _, v := range values {
+		dst = binary.BigEndian.AppendUint64(dst, uint64(v))
+	}
+
+	return dst
+}
+
+func stringSize(s string) int {
+	return 2 + len(s)
+}
+
+func writeTagType(w io.Writer, typ TagType) error {
+	var b [1]byte
+	return writeBytes(w, appendTagType(b[:0], typ))
+}
+
+func writeInt8(w io.Writer, v int8) error {
+	var b [1]byte
+	return writeBytes(w, appendInt8(b[:0], v))
+}
+
+func writeInt16(w io.Writer, v int16) error {
+	var b [2]byte
+	return writeBytes(w, appendInt16(b[:0], v))
+}
+
+func writeInt32(w io.Writer, v int32) error {
+	var b [4]byte
+	return writeBytes(w, appendInt32(b[:0], v))
+}
+
+func writeInt64(w io.Writer, v int64) error {
+	var b [8]byte
+	return writeBytes(w, appendInt64(b[:0], v))
+}
+
+func writeFloat32(w io.Writer, v float32) error {
+	var b [4]byte
+	return writeBytes(w, appendFloat32(b[:0], v))
+}
+
+func writeFloat64(w io.Writer, v float64) error {
+	var b [8]byte
+	return writeBytes(w, appendFloat64(b[:0], v))
+}
+
+func writeString(w io.Writer, s string) error {
+	return writeBytes(w, appendString(make([]byte, 0, stringSize(s)), s))
+}
+
+func writeLength(w io.Writer, l int) error {
+	return writeInt32(w, int32(l))
+}
+
+func writeInt8s(w io.Writer, values []int8) error {
+	return writeBytes(w, appendInt8s(make([]byte, 0, len(values)), values))
+}
+
+func writeInt32s(w io.Writer, values []int32) error {
+	return writeBytes(w, appendInt32s(make([]byte, 0, len(values)*4), values))
+}
+
+func writeInt64s(w io.Writer, values []int64) error {
+	return writeBytes(w, appendInt64s(make([]byte, 0, len(values)*8), values))
+}
+
+func writeBytes(w io.Writer, b []byte) error {
+	if _, err := w.Write(b); err != nil {
+		return &NbtError{Op: "encode", Err: err}
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"io"
+)
+
+type writerFrame struct {
+	typ       TagType
+	elemType  TagType
+	remaining int
+}
+
+type Writer struct {
+	w     io.Writer
+	opts  *EncodeOptions
+	stack []writerFrame
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return NewWriterWithOptions(w, new(EncodeOptions))
+}
+
+func NewWriterWithOptions(w io.Writer, opts *EncodeOptions) *Writer {
+	return &Writer{
+		w:    w,
+		opts: opts,
+	}
+}
+
+func (w *Writer) Depth() int {
+	return len(w.stack)
+}
+
+func (w *Writer) BeginCompound(name string) error {
+	if err := w.header(TagTypeCompound, name); err != nil {
+		logError(w.opts.logger(), "failed to write", "name", name, "error", err)
+		return err
+	}
+
+	w.stack = append(w.stack, writerFrame{typ: TagTypeCompound})
+
+	return nil
+}
+
+func (w *Writer) BeginList(name string, elemType TagType, n int) error {
+	if n < 0 {
+		err := &NbtError{Op: "encode", Err: ErrInvalidNesting}
+		logError(w.opts.logger(), "failed to write", "name", name, "error", err)
+		return err
+	}
+
+	if elemType > TagTypeLongArray || (elemType == TagTypeEnd && n > 0) {
+		err := &NbtError{Op: "encode", Err: ErrInvalidElementType}
+		logError(w.opts.logger(), "failed to write", "name", name, "error", err)
+		return err
+	}
+
+	if err := w.header(TagTypeList, name); err != nil {
+		logError(w.opts.logger(), "failed to write", "name", name, "error", err)
+		return err
+	}
+
+	if err := writeTagType(w.w, elemType); err != nil {
+		logError(w.opts.logger(), "failed to write", "name", name, "error", err)
+		return err
+	}
+
+	if err := writeLength(w.w, n); err != nil {
+		logError(w.opts.logger(), "failed to write", "name", name, "error", err)
+		return err
+	}
+
+	w.stack = append(w.stack, writerFrame{typ: TagTypeList, elemType: elemType, remaining: n})
+
+	return nil
+}
+
+func (w *Writer) End() error {
+	if len(w.stack) == 0 {
+		err := &NbtError{Op: "encode", Err: ErrInvalidNesting}
+		logError(w.opts.logger(), "failed to write", "error", err)
+		return err
+	}
+
+	frame := w.stack[len(w.stack)-1]
+	switch frame.typ {
+	case TagTypeCompound:
+		if err := writeTagType(w.w, TagTypeEnd); err != nil {
+			logError(w.opts.logger(), "failed to write", "error", err)
+			return err
+		}
+	case TagTypeList:
+		if frame.remaining != 0 {
+			err := &NbtError{Op: "encode", Err: ErrInvalidNesting}
+			logError(w.opts.logger(), "failed to write", "remaining", frame.remaining, "error", err)
+			return err
+		}
+	}
+
+	w.stack = w.stack[:len(w.stack)-1]
+
+	return nil
+}
+
+// NOTE: reports an error when a compound or list is left open, the underlying writer is not closed
+func (w *Writer) Close() error {
+	if len(w.stack) != 0 {
+		err := &NbtError{Op: "encode", Err: ErrInvalidNesting}
+		logError(w.opts.logger(), "failed to close", "depth", len(w.stack), "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (w *Writer) Byte(name string, v int8) error {
+	return w.scalar(TagTypeByte, name, func() error { return writeInt8(w.w, v) })
+}
+
+func (w *Writer) Short(name string, v int16) error {
+	return w.scalar(TagTypeShort, name, func() error { return writeInt16(w.w, v) })
+}
+
+func (w *Writer) Int(name string, v int32) error {
+	return w.scalar(TagTypeInt, name, func() error { return writeInt32(w.w, v) })
+}
+
+func (w *Writer) Long(name string, v int64) error {
+	return w.scalar(TagTypeLong, name, func() error { return writeInt64(w.w, v) })
+}
+
+func (w *Writer) Float(name string, v float32) error {
+	return w.scalar(TagTypeFloat, name, func() error { return writeFloat32(w.w, v) })
+}
+
+func (w *Writer) Double(name string, v float64) error {
+	return w.scalar(TagTypeDouble, name, func() error { return writeFloat64(w.w, v) })
+}
+
+func (w *Writer) String(name string, v string) error {
+	return w.scalar(TagTypeString, name, func() error { return writeString(w.w, v) })
+}
+
+func (w *Writer) ByteArray(name string, values []int8) error {
+	return w.scalar(TagTypeByteArray, name, func() error {
+		if err := writeLength(w.w, len(values)); err != nil {
+			return err
+		}
+
+		return writeInt8s(w.w, values)
+	})
+}
+
+func (w *Writer) IntArray(name string, values []int32) error {
+	return w.scalar(TagTypeIntArray, name, func() error {
+		if err := writeLength(w.w, len(values)); err != nil {
+			return err
+		}
+
+		return writeInt32s(w.w, values)
+	})
+}
+
+func (w *Writer) LongArray(name string, values []int64) error {
+	return w.scalar(TagTypeLongArray, name, func() error {
+		if err := writeLength(w.w, len(values)); err != nil {
+			return err
+		}
+
+		return writeInt64s(w.w, values)
+	})
+}
+
+// NOTE: writes a whole payload, e.g. a prebuilt CompoundPayload, at the current position
+func (w *Writer) Payload(name string, payload Payload) error {
+	return w.scalar(payload.TypeId(), name, func() error { return payload.EncodeNBT(w.w, w.opts) })
+}
+
+func (w *Writer) scalar(typ TagType, name string, write func() error) error {
+	if err := w.header(typ, name); err != nil {
+		logError(w.opts.logger(), "failed to write", "name", name, "error", err)
+		return err
+	}
+
+	if err := write(); err != nil {
+		logError(w.opts.logger(), "failed to write", "name", name, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// NOTE: list elements have no header, so their names are ignored
+func (w *Writer) header(typ TagType, name string) error {
+	if len(w.stack) == 0 || w.stack[len(w.stack)-1].typ == TagTypeCompound {
+		if err := writeTagType(w.w, typ); err != nil {
+			return err
+		}
+
+		return writeString(w.w, name)
+	}
+
+	frame := &w.stack[len(w.stack)-1]
+	if frame.remaining == 0 {
+		return &NbtError{Op: "encode", Err: ErrInvalidNesting}
+	}
+
+	if typ != frame.elemType {
+		return &NbtError{Op: "encode", Err: ErrInvalidElementType}
+	}
+
+	frame.remaining--
+
+	return nil
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2022 Aton-Kish
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nbt
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Aton-Kish/gonbt/slices"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestPayload(t *testing.T, w *Writer, name string, payload Payload) {
+	var err error
+	switch p := payload.(type) {
+	case *BytePayload:
+		err = w.Byte(name, int8(*p))
+	case *ShortPayload:
+		err = w.Short(name, int16(*p))
+	case *IntPayload:
+		err = w.Int(name, int32(*p))
+	case *LongPayload:
+		err = w.Long(name, int64(*p))
+	case *FloatPayload:
+		err = w.Float(name, float32(*p))
+	case *DoublePayload:
+		err = w.Double(name, float64(*p))
+	case *ByteArrayPayload:
+		err = w.ByteArray(name, []int8(*p))
+	case *StringPayload:
+		err = w.String(name, string(*p))
+	case *ListPayload:
+		err = w.BeginList(name, p.ElemType(), p.Len())
+		assert.NoError(t, err)
+
+		for _, elem := range p.Values() {
+			writeTestPayload(t, w, "", elem)
+		}
+
+		err = w.End()
+	case *CompoundPayload:
+		err = w.BeginCompound(name)
+		assert.NoError(t, err)
+
+		for _, tag := range *p {
+			if tag.TypeId() == TagTypeEnd {
+				continue
+			}
+
+			writeTestPayload(t, w, string(*tag.TagName()), tag.Payload())
+		}
+
+		err = w.End()
+	case *IntArrayPayload:
+		err = w.IntArray(name, []int32(*p))
+	case *LongArrayPayload:
+		err = w.LongArray(name, []int64(*p))
+	}
+
+	assert.NoError(t, err)
+}
+
+func TestWriter(t *testing.T) {
+	type Case struct {
+		name     string
+		nbt      Tag
+		expected []byte
+	}
+
+	cases := []Case{}
+
+	for _, c := range excludeEndTagCases {
+		c := c
+		tag, err := newTagWithPayload(&c.nbt.tagName, c.nbt.payload)
+		assert.NoError(t, err)
+
+		cases = append(cases, Case{
+			name:     c.name,
+			nbt:      tag,
+			expected: slices.Concat(c.raw.tagType, c.raw.tagName, c.raw.payload),
+		})
+	}
+
+	for _, c := range nbtCases {
+		cases = append(cases, Case{
+			name:     c.name,
+			nbt:      c.nbt,
+			expected: c.raw,
+		})
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			w := NewWriter(buf)
+
+			writeTestPayload(t, w, string(*tt.nbt.TagName()), tt.nbt.Payload())
+
+			err := w.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, buf.Bytes())
+
+			encoded := new(bytes.Buffer)
+			err = Encode(encoded, tt.nbt)
+			assert.NoError(t, err)
+			assert.Equal(t, encoded.Bytes(), buf.Bytes())
+		})
+	}
+}
+
+func TestWriter_Payload(t *testing.T) {
+	c := nbtCases[1]
+
+	buf := new(bytes.Buffer)
+	w := NewWriter(buf)
+
+	err := w.Payload(string(*c.nbt.TagName()), c.nbt.Payload())
+	assert.NoError(t, err)
+	assert.Equal(t, c.raw, buf.Bytes())
+}
+
+func TestWriter_error(t *testing.T) {
+	cases := []struct {
+		name        string
+		write       func(w *Writer) error
+		expectedErr error
+	}{
+		{
+			name: `negative case: End without Begin`,
+			write: func(w *Writer) error {
+				return w.End()
+			},
+			expectedErr: &NbtError{Op: "encode", Err: ErrInvalidNesting},
+		},
+		{
+			name: `negative case: too few list elements`,
+			write: func(w *Writer) error {
+				if err := w.BeginList("List", TagTypeInt, 2); err != nil {
+					return err
+				}
+
+				if err := w.Int("", 1); err != nil {
+					return err
+				}
+
+				return w.End()
+			},
+			expectedErr: &NbtError{Op: "encode", Err: ErrInvalidNesting},
+		},
+		{
+			name: `negative case: too many list elements`,
+			write: func(w *Writer) error {
+				if err := w.BeginList("List", TagTypeInt, 1); err != nil {
+					return err
+				}
+
+				if err := w.Int("", 1); err != nil {
+					return err
+				}
+
+				return w.Int("", 2)
+			},
+			expectedErr: &NbtError{Op: "encode", Err: ErrInvalidNesting},
+		},
+		{
+			name: `negative case: mismatched list element`,
+			write: func(w *Writer) error {
+				if err := w.BeginList("List", TagTypeInt, 1); err != nil {
+					return err
+				}
+
+				return w.Long("", 1)
+			},
+			expectedErr: &NbtError{Op: "encode", Err: ErrInvalidElementType},
+		},
+		{
+			name: `negative case: End list with elements`,
+			write: func(w *Writer) error {
+				return w.BeginList("List", TagTypeEnd, 1)
+			},
+			expectedErr: &NbtError{Op: "encode", Err: ErrInvalidElementType},
+		},
+		{
+			name: `negative case: negative list length`,
+			write: func(w *Writer) error {
+				return w.BeginList("List", TagTypeInt, -1)
+			},
+			expectedErr: &NbtError{Op: "encode", Err: ErrInvalidNesting},
+		},
+		{
+			name: `negative case: unclosed compound`,
+			write: func(w *Writer) error {
+				if err := w.BeginCompound(""); err != nil {
+					return err
+				}
+
+				return w.Close()
+			},
+			expectedErr: &NbtError{Op: "encode", Err: ErrInvalidNesting},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWriter(new(bytes.Buffer))
+			err := tt.write(w)
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}